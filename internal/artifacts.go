@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"spacecore_registry/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var platformPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// normalizePlatforms lowercases and deduplicates declared platforms. Every
+// platform needs an os; the arch may be left empty to cover all of them.
+func normalizePlatforms(platforms []*pb.Platform) ([]*pb.Platform, error) {
+	var out []*pb.Platform
+	for _, p := range platforms {
+		p = &pb.Platform{Os: strings.ToLower(strings.TrimSpace(p.Os)), Arch: strings.ToLower(strings.TrimSpace(p.Arch))}
+		if !platformPattern.MatchString(p.Os) || (p.Arch != "" && !platformPattern.MatchString(p.Arch)) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid platform %q", platformString(p))
+		}
+		if !slices.ContainsFunc(out, func(q *pb.Platform) bool { return samePlatform(p, q) }) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func platformString(p *pb.Platform) string {
+	if p.Arch == "" {
+		return p.Os
+	}
+	return p.Os + "/" + p.Arch
+}
+
+// platformList describes the platforms of an artifact for messages.
+func platformList(platforms []*pb.Platform) string {
+	if len(platforms) == 0 {
+		return "any"
+	}
+	names := make([]string, 0, len(platforms))
+	for _, p := range platforms {
+		names = append(names, platformString(p))
+	}
+	return strings.Join(names, ", ")
+}
+
+func samePlatform(a, b *pb.Platform) bool {
+	return a.Os == b.Os && a.Arch == b.Arch
+}
+
+// platformsOverlap reports whether a build for a could also be picked for b.
+func platformsOverlap(a, b *pb.Platform) bool {
+	return a.Os == b.Os && (a.Arch == "" || b.Arch == "" || a.Arch == b.Arch)
+}
+
+// artifactsOf returns the artifacts of a plugin version. Versions registered
+// before artifacts existed are treated as a single artifact.
+func artifactsOf(p *pb.Plugin) []*pb.Artifact {
+	if len(p.Artifacts) > 0 {
+		return p.Artifacts
+	}
+	return []*pb.Artifact{{
+		Cid:       p.Cid,
+		Platforms: p.Platforms,
+		Publisher: p.Publisher,
+		Signature: p.Signature,
+		PublicKey: p.PublicKey,
+		Sha256:    p.Sha256,
+		Size:      p.Size,
+	}}
+}
+
+// findArtifact returns the artifact with the given bare root CID, or nil.
+func findArtifact(p *pb.Plugin, root string) *pb.Artifact {
+	for _, a := range artifactsOf(p) {
+		if strings.TrimPrefix(a.Cid, "/ipfs/") == root {
+			return a
+		}
+	}
+	return nil
+}
+
+// artifactConflict explains why a cannot be added to p, or returns nil.
+// Artifacts without platforms run anywhere, so they can't share a version.
+func artifactConflict(p *pb.Plugin, a *pb.Artifact) error {
+	for _, existing := range artifactsOf(p) {
+		if len(existing.Platforms) == 0 || len(a.Platforms) == 0 {
+			return status.Errorf(codes.AlreadyExists, "%s:%s is already registered with CID %s", p.Name, p.Version, existing.Cid)
+		}
+		for _, ep := range existing.Platforms {
+			for _, ap := range a.Platforms {
+				if platformsOverlap(ep, ap) {
+					return status.Errorf(codes.AlreadyExists, "%s:%s already has a %s build with CID %s", p.Name, p.Version, platformString(ep), existing.Cid)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// selectArtifact picks the artifact of p that runs on os and arch, preferring
+// an exact platform match over a platform independent build. Either may be
+// empty to match anything.
+func selectArtifact(p *pb.Plugin, os, arch string) (*pb.Artifact, error) {
+	os, arch = strings.ToLower(os), strings.ToLower(arch)
+	var fallback *pb.Artifact
+	var available []string
+	for _, a := range artifactsOf(p) {
+		if len(a.Platforms) == 0 {
+			if fallback == nil {
+				fallback = a
+			}
+			available = append(available, "any")
+			continue
+		}
+		for _, pl := range a.Platforms {
+			if (os == "" || pl.Os == os) && (arch == "" || pl.Arch == "" || pl.Arch == arch) {
+				return a, nil
+			}
+			available = append(available, platformString(pl))
+		}
+	}
+	if fallback != nil {
+		return fallback, nil
+	}
+	want := platformString(&pb.Platform{Os: os, Arch: arch})
+	if os == "" {
+		want = "*/" + arch
+	}
+	return nil, status.Errorf(codes.NotFound, "%s:%s has no build for %s (available: %s)", p.Name, p.Version, want, strings.Join(available, ", "))
+}
+
+// useArtifact points the top-level CID, signature and digest of p at a.
+func useArtifact(p *pb.Plugin, a *pb.Artifact) {
+	p.Cid = a.Cid
+	p.Publisher = a.Publisher
+	p.Signature = a.Signature
+	p.PublicKey = a.PublicKey
+	p.Sha256 = a.Sha256
+	p.Size = a.Size
+}
+
+// samePlatforms reports whether a and b list the same platforms, in any
+// order.
+func samePlatforms(a, b []*pb.Platform) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, p := range a {
+		if !slices.ContainsFunc(b, func(q *pb.Platform) bool { return samePlatform(p, q) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergePlatforms adds the platforms of a new artifact to the version's list.
+func mergePlatforms(platforms, add []*pb.Platform) []*pb.Platform {
+	for _, p := range add {
+		if !slices.ContainsFunc(platforms, func(q *pb.Platform) bool { return samePlatform(p, q) }) {
+			platforms = append(platforms, p)
+		}
+	}
+	return platforms
+}
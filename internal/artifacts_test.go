@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"spacecore_registry/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func platforms(specs ...string) []*pb.Platform {
+	var out []*pb.Platform
+	for _, s := range specs {
+		os, arch, _ := strings.Cut(s, "/")
+		out = append(out, &pb.Platform{Os: os, Arch: arch})
+	}
+	return out
+}
+
+func TestArtifactConflict(t *testing.T) {
+	plugin := &pb.Plugin{Name: "a", Version: "1.0.0", Artifacts: []*pb.Artifact{
+		{Cid: "/ipfs/linux-amd64", Platforms: platforms("linux/amd64")},
+		{Cid: "/ipfs/darwin", Platforms: platforms("darwin")},
+	}}
+	legacy := &pb.Plugin{Name: "a", Version: "0.1.0", Cid: "/ipfs/any"}
+
+	tests := []struct {
+		name     string
+		plugin   *pb.Plugin
+		add      []*pb.Platform
+		wantCode codes.Code
+	}{
+		{"new os", plugin, platforms("windows/amd64"), codes.OK},
+		{"new arch", plugin, platforms("linux/arm64"), codes.OK},
+		{"same platform", plugin, platforms("linux/amd64"), codes.AlreadyExists},
+		{"every arch of a taken os", plugin, platforms("linux"), codes.AlreadyExists},
+		{"arch of an os built for every arch", plugin, platforms("darwin/arm64"), codes.AlreadyExists},
+		{"one of several overlaps", plugin, platforms("windows", "linux/amd64"), codes.AlreadyExists},
+		{"platform independent", plugin, nil, codes.AlreadyExists},
+		{"next to a platform independent build", legacy, platforms("linux"), codes.AlreadyExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := artifactConflict(tt.plugin, &pb.Artifact{Cid: "/ipfs/new", Platforms: tt.add})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("artifactConflict = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSelectArtifact(t *testing.T) {
+	plugin := &pb.Plugin{Name: "a", Version: "1.0.0", Artifacts: []*pb.Artifact{
+		{Cid: "/ipfs/any", Platforms: nil},
+		{Cid: "/ipfs/linux-amd64", Platforms: platforms("linux/amd64")},
+		{Cid: "/ipfs/darwin", Platforms: platforms("darwin")},
+	}}
+	native := &pb.Plugin{Name: "a", Version: "1.1.0", Artifacts: []*pb.Artifact{
+		{Cid: "/ipfs/linux-amd64", Platforms: platforms("linux/amd64")},
+		{Cid: "/ipfs/windows", Platforms: platforms("windows/amd64", "windows/arm64")},
+	}}
+
+	tests := []struct {
+		name     string
+		plugin   *pb.Plugin
+		os, arch string
+		want     string
+		wantCode codes.Code
+	}{
+		{"exact platform", plugin, "linux", "amd64", "/ipfs/linux-amd64", codes.OK},
+		{"case insensitive", plugin, "Linux", "AMD64", "/ipfs/linux-amd64", codes.OK},
+		{"os built for every arch", plugin, "darwin", "arm64", "/ipfs/darwin", codes.OK},
+		{"falls back to any", plugin, "linux", "arm64", "/ipfs/any", codes.OK},
+		{"any os", native, "", "arm64", "/ipfs/windows", codes.OK},
+		{"any arch", native, "windows", "", "/ipfs/windows", codes.OK},
+		{"nothing asked", native, "", "", "/ipfs/linux-amd64", codes.OK},
+		{"no build", native, "darwin", "arm64", "", codes.NotFound},
+		{"legacy record", &pb.Plugin{Name: "a", Version: "0.1.0", Cid: "/ipfs/old"}, "linux", "amd64", "/ipfs/old", codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectArtifact(tt.plugin, tt.os, tt.arch)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("error = %v, want code %s", err, tt.wantCode)
+			}
+			if err == nil && got.Cid != tt.want {
+				t.Errorf("selected %s, want %s", got.Cid, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,466 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"spacecore_registry/internal/content"
+	"spacecore_registry/internal/pinning"
+	"spacecore_registry/internal/store"
+
+	"github.com/joho/godotenv"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the registry's runtime configuration. Load fills it from, in
+// increasing order of precedence, built-in defaults, a YAML file, the
+// environment (including a .env file) and command-line flags.
+type Config struct {
+	GRPC    GRPCConfig    `yaml:"grpc"`
+	P2P     P2PConfig     `yaml:"p2p"`
+	IPFS    IPFSConfig    `yaml:"ipfs"`
+	Store   StoreConfig   `yaml:"store"`
+	Pinning PinningConfig `yaml:"pinning"`
+	Admin   AdminConfig   `yaml:"admin"`
+	// ShutdownTimeout bounds how long stopping takes, including draining
+	// in-flight RPCs and flushing pending pin jobs.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+type GRPCConfig struct {
+	// Addr is the host:port the gRPC server listens on.
+	Addr string `yaml:"addr"`
+	// MaxUploadSize caps the bytes accepted for one plugin binary.
+	MaxUploadSize int64 `yaml:"max_upload_size"`
+}
+
+type AdminConfig struct {
+	// Addr is the host:port of the HTTP admin endpoints. Empty disables
+	// them.
+	Addr string `yaml:"addr"`
+}
+
+type P2PConfig struct {
+	// ListenAddrs are the libp2p host's listen multiaddrs.
+	ListenAddrs []string `yaml:"listen_addrs"`
+	// IdentityPath is the file holding the host's private key. It is
+	// created on first start, so the peer ID and IPNS name stay the same
+	// across restarts.
+	IdentityPath string `yaml:"identity_path"`
+	// BootstrapPeers are /p2p multiaddrs of DHT peers to join through, in
+	// addition to the default IPFS bootstrap peers.
+	BootstrapPeers []string `yaml:"bootstrap_peers"`
+}
+
+type IPFSConfig struct {
+	// Backend is "kubo" or "embedded".
+	Backend string `yaml:"backend"`
+	// API is the kubo RPC endpoint as a multiaddr or http(s) URL. Empty uses
+	// the API file of the local IPFS repo.
+	API string `yaml:"api"`
+	// RepoPath is where the embedded node keeps its data.
+	RepoPath string `yaml:"repo_path"`
+}
+
+type StoreConfig struct {
+	// Backend is "redis" or "bolt".
+	Backend   string `yaml:"backend"`
+	RedisAddr string `yaml:"redis_addr"`
+	BoltPath  string `yaml:"bolt_path"`
+}
+
+type PinningConfig struct {
+	// Providers lists the pinning providers in order. Empty means Pinata
+	// when a JWT is set, and no remote pinning otherwise.
+	Providers []string `yaml:"providers"`
+	// MinReplicas is the default replication policy. Nil means one provider
+	// when any are configured.
+	MinReplicas *int            `yaml:"min_replicas"`
+	Pinata      PinataConfig    `yaml:"pinata"`
+	Services    []ServiceConfig `yaml:"services"`
+}
+
+type PinataConfig struct {
+	JWT       string   `yaml:"jwt"`
+	HostNodes []string `yaml:"host_nodes"`
+}
+
+// ServiceConfig is an IPFS Pinning Service API endpoint.
+type ServiceConfig struct {
+	Name     string   `yaml:"name"`
+	Endpoint string   `yaml:"endpoint"`
+	Token    string   `yaml:"token"`
+	Origins  []string `yaml:"origins"`
+}
+
+func Default() *Config {
+	return &Config{
+		GRPC:  GRPCConfig{Addr: ":50051", MaxUploadSize: 256 << 20},
+		P2P:   P2PConfig{ListenAddrs: []string{"/ip4/0.0.0.0/tcp/0"}, IdentityPath: "spacecore-identity.key"},
+		IPFS:  IPFSConfig{Backend: content.BackendKubo, RepoPath: "spacecore-ipfs"},
+		Store: StoreConfig{Backend: store.BackendRedis, RedisAddr: "0.0.0.0:6379", BoltPath: "spacecore-registry.db"},
+
+		Admin:           AdminConfig{Addr: ":9090"},
+		ShutdownTimeout: 30 * time.Second,
+	}
+}
+
+// flagValues holds command-line overrides; only flags that were set apply.
+type flagValues struct {
+	config, grpcAddr, p2pListen, p2pBootstrap, p2pIdentity, ipfsBackend, ipfsAPI, ipfsRepo string
+	store, redisAddr, boltPath, adminAddr                                                  string
+	providers, pinataHostNodes, minReplicas                                                string
+	maxUploadSize                                                                          int64
+	shutdownTimeout                                                                        time.Duration
+}
+
+// Load builds the configuration for a process started with args, which
+// exclude the program name. It returns flag.ErrHelp when -h was given.
+func Load(args []string) (*Config, error) {
+	var fv flagValues
+	fs := flag.NewFlagSet("spacecore-registry", flag.ContinueOnError)
+	fs.StringVar(&fv.config, "config", "", "path to a YAML config file (env CONFIG_FILE)")
+	fs.StringVar(&fv.grpcAddr, "grpc-addr", "", "gRPC listen address (env GRPC_ADDR, default :50051)")
+	fs.Int64Var(&fv.maxUploadSize, "max-upload-size", 0, "largest plugin binary accepted, in bytes (env MAX_UPLOAD_SIZE, default 256 MiB)")
+	fs.StringVar(&fv.p2pListen, "p2p-listen", "", "comma separated libp2p listen multiaddrs (env P2P_LISTEN_ADDRS)")
+	fs.StringVar(&fv.p2pIdentity, "p2p-identity", "", "libp2p private key file, created if missing (env P2P_IDENTITY_PATH, default spacecore-identity.key)")
+	fs.StringVar(&fv.p2pBootstrap, "p2p-bootstrap", "", "comma separated DHT bootstrap peer multiaddrs, added to the IPFS defaults (env P2P_BOOTSTRAP_PEERS)")
+	fs.StringVar(&fv.ipfsBackend, "ipfs-backend", "", "kubo or embedded (env IPFS_BACKEND)")
+	fs.StringVar(&fv.ipfsAPI, "ipfs-api", "", "kubo RPC API multiaddr or URL (env IPFS_API)")
+	fs.StringVar(&fv.ipfsRepo, "ipfs-repo", "", "embedded IPFS repo path (env IPFS_REPO_PATH)")
+	fs.StringVar(&fv.store, "store", "", "redis or bolt (env METADATA_STORE)")
+	fs.StringVar(&fv.redisAddr, "redis-addr", "", "Redis host:port (env REDIS_ADDR)")
+	fs.StringVar(&fv.boltPath, "bolt-path", "", "bolt database file (env BOLT_PATH)")
+	fs.StringVar(&fv.providers, "pinning-providers", "", "comma separated pinning providers (env PINNING_PROVIDERS)")
+	fs.StringVar(&fv.pinataHostNodes, "pinata-host-nodes", "", "comma separated Pinata host node multiaddrs (env PINATA_HOST_NODES)")
+	fs.StringVar(&fv.minReplicas, "min-replicas", "", "default replication policy (env MIN_REPLICAS)")
+	fs.StringVar(&fv.adminAddr, "admin-addr", "", "admin HTTP listen address; -admin-addr= disables it (env ADMIN_ADDR, default :9090)")
+	fs.DurationVar(&fv.shutdownTimeout, "shutdown-timeout", 0, "how long to wait for a graceful shutdown (env SHUTDOWN_TIMEOUT, default 30s)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	// .env is optional; the process environment still applies without it.
+	_ = godotenv.Load()
+
+	cfg := Default()
+	path := os.Getenv("CONFIG_FILE")
+	if set["config"] {
+		path = fv.config
+	}
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	if err := cfg.applyFlags(fv, set); err != nil {
+		return nil, err
+	}
+	if len(cfg.Pinning.Providers) == 0 && cfg.Pinning.Pinata.JWT != "" {
+		cfg.Pinning.Providers = []string{pinning.ProviderPinata}
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv overrides settings with any environment variables that are set
+// to a non-empty value.
+func (c *Config) applyEnv() error {
+	setString(&c.GRPC.Addr, os.Getenv("GRPC_ADDR"))
+	setList(&c.P2P.ListenAddrs, os.Getenv("P2P_LISTEN_ADDRS"))
+	setString(&c.P2P.IdentityPath, os.Getenv("P2P_IDENTITY_PATH"))
+	setList(&c.P2P.BootstrapPeers, os.Getenv("P2P_BOOTSTRAP_PEERS"))
+	setString(&c.IPFS.Backend, os.Getenv("IPFS_BACKEND"))
+	setString(&c.IPFS.API, os.Getenv("IPFS_API"))
+	setString(&c.IPFS.RepoPath, os.Getenv("IPFS_REPO_PATH"))
+	setString(&c.Store.Backend, os.Getenv("METADATA_STORE"))
+	setString(&c.Store.RedisAddr, os.Getenv("REDIS_ADDR"))
+	setString(&c.Store.BoltPath, os.Getenv("BOLT_PATH"))
+	setString(&c.Admin.Addr, os.Getenv("ADMIN_ADDR"))
+	setList(&c.Pinning.Providers, os.Getenv("PINNING_PROVIDERS"))
+	setString(&c.Pinning.Pinata.JWT, os.Getenv("JWT"))
+	setList(&c.Pinning.Pinata.HostNodes, os.Getenv("PINATA_HOST_NODES"))
+	if err := setInt(&c.Pinning.MinReplicas, "MIN_REPLICAS", os.Getenv("MIN_REPLICAS")); err != nil {
+		return err
+	}
+	if v := os.Getenv("MAX_UPLOAD_SIZE"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("MAX_UPLOAD_SIZE %q is not an integer", v)
+		}
+		c.GRPC.MaxUploadSize = n
+	}
+	if v := os.Getenv("SHUTDOWN_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("SHUTDOWN_TIMEOUT %q is not a duration", v)
+		}
+		c.ShutdownTimeout = d
+	}
+
+	// PINNING_SERVICE_ENDPOINT configures a service named "pinning-service".
+	// Further services, such as a self-hosted cluster, are listed in
+	// PINNING_SERVICES as name=endpoint pairs with tokens in
+	// PINNING_SERVICE_TOKEN_<NAME>. Either replaces the services from the
+	// config file.
+	var services []ServiceConfig
+	origins := splitList(os.Getenv("PINNING_SERVICE_ORIGINS"))
+	if endpoint := os.Getenv("PINNING_SERVICE_ENDPOINT"); endpoint != "" {
+		services = append(services, ServiceConfig{
+			Name:     pinning.ProviderService,
+			Endpoint: endpoint,
+			Token:    os.Getenv("PINNING_SERVICE_TOKEN"),
+			Origins:  origins,
+		})
+	}
+	for _, entry := range splitList(os.Getenv("PINNING_SERVICES")) {
+		name, endpoint, _ := strings.Cut(entry, "=")
+		services = append(services, ServiceConfig{
+			Name:     name,
+			Endpoint: endpoint,
+			Token:    os.Getenv("PINNING_SERVICE_TOKEN_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))),
+			Origins:  origins,
+		})
+	}
+	if len(services) > 0 {
+		c.Pinning.Services = services
+	}
+	return nil
+}
+
+func (c *Config) applyFlags(fv flagValues, set map[string]bool) error {
+	apply := func(name string, dst *string, v string) {
+		if set[name] {
+			*dst = v
+		}
+	}
+	apply("grpc-addr", &c.GRPC.Addr, fv.grpcAddr)
+	apply("p2p-identity", &c.P2P.IdentityPath, fv.p2pIdentity)
+	apply("ipfs-backend", &c.IPFS.Backend, fv.ipfsBackend)
+	apply("ipfs-api", &c.IPFS.API, fv.ipfsAPI)
+	apply("ipfs-repo", &c.IPFS.RepoPath, fv.ipfsRepo)
+	apply("store", &c.Store.Backend, fv.store)
+	apply("redis-addr", &c.Store.RedisAddr, fv.redisAddr)
+	apply("bolt-path", &c.Store.BoltPath, fv.boltPath)
+	apply("admin-addr", &c.Admin.Addr, fv.adminAddr)
+	if set["p2p-listen"] {
+		c.P2P.ListenAddrs = splitList(fv.p2pListen)
+	}
+	if set["p2p-bootstrap"] {
+		c.P2P.BootstrapPeers = splitList(fv.p2pBootstrap)
+	}
+	if set["pinning-providers"] {
+		c.Pinning.Providers = splitList(fv.providers)
+	}
+	if set["pinata-host-nodes"] {
+		c.Pinning.Pinata.HostNodes = splitList(fv.pinataHostNodes)
+	}
+	if set["max-upload-size"] {
+		c.GRPC.MaxUploadSize = fv.maxUploadSize
+	}
+	if set["shutdown-timeout"] {
+		c.ShutdownTimeout = fv.shutdownTimeout
+	}
+	if set["min-replicas"] {
+		return setInt(&c.Pinning.MinReplicas, "-min-replicas", fv.minReplicas)
+	}
+	return nil
+}
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if _, port, err := net.SplitHostPort(c.GRPC.Addr); err != nil {
+		fail("grpc.addr %q: %v", c.GRPC.Addr, err)
+	} else if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		fail("grpc.addr %q: invalid port", c.GRPC.Addr)
+	}
+	if c.GRPC.MaxUploadSize <= 0 {
+		fail("grpc.max_upload_size %d must be positive", c.GRPC.MaxUploadSize)
+	}
+
+	if c.Admin.Addr != "" {
+		if _, _, err := net.SplitHostPort(c.Admin.Addr); err != nil {
+			fail("admin.addr %q: %v", c.Admin.Addr, err)
+		}
+	}
+
+	if len(c.P2P.ListenAddrs) == 0 {
+		fail("p2p.listen_addrs must not be empty")
+	}
+	for _, addr := range c.P2P.ListenAddrs {
+		if _, err := ma.NewMultiaddr(addr); err != nil {
+			fail("p2p.listen_addrs %q: %v", addr, err)
+		}
+	}
+	if c.P2P.IdentityPath == "" {
+		fail("p2p.identity_path must not be empty")
+	}
+	for _, addr := range c.P2P.BootstrapPeers {
+		if _, err := peer.AddrInfoFromString(addr); err != nil {
+			fail("p2p.bootstrap_peers %q: %v", addr, err)
+		}
+	}
+
+	switch c.IPFS.Backend {
+	case content.BackendKubo, content.BackendEmbedded:
+	default:
+		fail("ipfs.backend %q: want kubo or embedded", c.IPFS.Backend)
+	}
+	if c.IPFS.API != "" {
+		if strings.HasPrefix(c.IPFS.API, "/") {
+			if _, err := ma.NewMultiaddr(c.IPFS.API); err != nil {
+				fail("ipfs.api %q: %v", c.IPFS.API, err)
+			}
+		} else if !isHTTPURL(c.IPFS.API) {
+			fail("ipfs.api %q: want a multiaddr or http(s) URL", c.IPFS.API)
+		}
+	}
+	if c.IPFS.Backend == content.BackendEmbedded && c.IPFS.RepoPath == "" {
+		fail("ipfs.repo_path is required for the embedded backend")
+	}
+
+	switch c.Store.Backend {
+	case store.BackendRedis:
+		if _, _, err := net.SplitHostPort(c.Store.RedisAddr); err != nil {
+			fail("store.redis_addr %q: %v", c.Store.RedisAddr, err)
+		}
+	case store.BackendBolt:
+		if c.Store.BoltPath == "" {
+			fail("store.bolt_path is required for the bolt backend")
+		}
+	default:
+		fail("store.backend %q: want redis or bolt", c.Store.Backend)
+	}
+
+	services := make(map[string]bool)
+	for _, s := range c.Pinning.Services {
+		if s.Name == "" {
+			fail("pinning.services: every service needs a name")
+			continue
+		}
+		if services[s.Name] {
+			fail("pinning.services: %q listed twice", s.Name)
+		}
+		services[s.Name] = true
+		if !isHTTPURL(s.Endpoint) {
+			fail("pinning.services %q: endpoint %q is not an http(s) URL", s.Name, s.Endpoint)
+		}
+	}
+	providers := len(c.Pinning.Providers)
+	for _, p := range c.Pinning.Providers {
+		switch {
+		case p == pinning.ProviderNone:
+			providers--
+			if len(c.Pinning.Providers) > 1 {
+				fail("pinning provider none can't be combined with other providers")
+			}
+		case p == pinning.ProviderPinata:
+			if c.Pinning.Pinata.JWT == "" {
+				fail("pinning provider pinata requires pinning.pinata.jwt (env JWT)")
+			}
+		case p == pinning.ProviderLocal, services[p]:
+		default:
+			fail("pinning provider %q is neither built in nor a configured service", p)
+		}
+	}
+	for _, node := range c.Pinning.Pinata.HostNodes {
+		if _, err := ma.NewMultiaddr(node); err != nil {
+			fail("pinning.pinata.host_nodes %q: %v", node, err)
+		}
+	}
+	if n := c.Pinning.MinReplicas; n != nil && (*n < 0 || *n > providers) {
+		fail("pinning.min_replicas %d must be between 0 and the %d configured providers", *n, providers)
+	}
+
+	if c.ShutdownTimeout <= 0 {
+		fail("shutdown_timeout %s must be positive", c.ShutdownTimeout)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// DefaultMinReplicas returns the replication policy for namespaces without
+// their own, given the number of pinning providers in use.
+func (c *Config) DefaultMinReplicas(providers int) int {
+	if c.Pinning.MinReplicas != nil {
+		return *c.Pinning.MinReplicas
+	}
+	return min(1, providers)
+}
+
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func setString(dst *string, v string) {
+	if v != "" {
+		*dst = v
+	}
+}
+
+func setList(dst *[]string, v string) {
+	if items := splitList(v); len(items) > 0 {
+		*dst = items
+	}
+}
+
+func setInt(dst **int, name, v string) error {
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("%s %q is not an integer", name, v)
+	}
+	*dst = &n
+	return nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
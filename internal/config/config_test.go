@@ -0,0 +1,167 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+var envVars = []string{
+	"CONFIG_FILE", "GRPC_ADDR", "P2P_LISTEN_ADDRS", "P2P_BOOTSTRAP_PEERS", "P2P_IDENTITY_PATH", "IPFS_BACKEND", "IPFS_API", "IPFS_REPO_PATH",
+	"METADATA_STORE", "REDIS_ADDR", "BOLT_PATH", "ADMIN_ADDR", "PINNING_PROVIDERS", "JWT",
+	"PINATA_HOST_NODES", "MIN_REPLICAS", "MAX_UPLOAD_SIZE", "SHUTDOWN_TIMEOUT", "PINNING_SERVICE_ENDPOINT",
+	"PINNING_SERVICE_TOKEN", "PINNING_SERVICE_ORIGINS", "PINNING_SERVICES",
+}
+
+// setEnv clears every variable Load reads, so the test does not depend on
+// the environment it runs in, and then sets env.
+func setEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+	for _, name := range envVars {
+		t.Setenv(name, "")
+	}
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const testYAML = `
+grpc:
+  addr: ":6000"
+store:
+  backend: bolt
+  bolt_path: /var/lib/file.db
+admin:
+  addr: ":7000"
+shutdown_timeout: 10s
+`
+
+func TestLoadPrecedence(t *testing.T) {
+	file := writeConfig(t, testYAML)
+	other := writeConfig(t, "grpc:\n  addr: \":6500\"\n")
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+		// want holds grpc addr, store backend, bolt path and admin addr.
+		want     [4]string
+		wantStop time.Duration
+	}{
+		{"defaults", nil, nil, [4]string{":50051", "redis", "spacecore-registry.db", ":9090"}, 30 * time.Second},
+		{"file over defaults", nil, []string{"-config", file}, [4]string{":6000", "bolt", "/var/lib/file.db", ":7000"}, 10 * time.Second},
+		{"file from env", map[string]string{"CONFIG_FILE": file}, nil, [4]string{":6000", "bolt", "/var/lib/file.db", ":7000"}, 10 * time.Second},
+		{"config flag over env", map[string]string{"CONFIG_FILE": file}, []string{"-config", other}, [4]string{":6500", "redis", "spacecore-registry.db", ":9090"}, 30 * time.Second},
+		{"env over file", map[string]string{"GRPC_ADDR": ":6001", "BOLT_PATH": "/env.db", "SHUTDOWN_TIMEOUT": "5s"}, []string{"-config", file},
+			[4]string{":6001", "bolt", "/env.db", ":7000"}, 5 * time.Second},
+		{"flags over env", map[string]string{"GRPC_ADDR": ":6001", "METADATA_STORE": "bolt"}, []string{"-config", file, "-grpc-addr", ":6002", "-shutdown-timeout", "1s"},
+			[4]string{":6002", "bolt", "/var/lib/file.db", ":7000"}, time.Second},
+		{"empty flag disables admin", map[string]string{"ADMIN_ADDR": ":7001"}, []string{"-admin-addr="}, [4]string{":50051", "redis", "spacecore-registry.db", ""}, 30 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+			cfg, err := Load(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := [4]string{cfg.GRPC.Addr, cfg.Store.Backend, cfg.Store.BoltPath, cfg.Admin.Addr}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if cfg.ShutdownTimeout != tt.wantStop {
+				t.Errorf("shutdown timeout = %s, want %s", cfg.ShutdownTimeout, tt.wantStop)
+			}
+		})
+	}
+}
+
+func TestLoadPinning(t *testing.T) {
+	file := writeConfig(t, `
+pinning:
+  providers: [service]
+  services:
+    - name: service
+      endpoint: https://file.example.com
+`)
+	tests := []struct {
+		name          string
+		env           map[string]string
+		args          []string
+		wantProviders []string
+		wantEndpoint  string
+		wantReplicas  int
+	}{
+		{"pinata implied by jwt", map[string]string{"JWT": "token"}, nil, []string{"pinata"}, "", 1},
+		{"file services", nil, []string{"-config", file}, []string{"service"}, "https://file.example.com", 1},
+		{"env replaces file services", map[string]string{"PINNING_SERVICE_ENDPOINT": "https://env.example.com", "PINNING_PROVIDERS": "pinning-service"},
+			[]string{"-config", file}, []string{"pinning-service"}, "https://env.example.com", 1},
+		{"flags over env", map[string]string{"JWT": "token", "PINNING_PROVIDERS": "pinata", "MIN_REPLICAS": "1"},
+			[]string{"-config", file, "-pinning-providers", "pinata,service", "-min-replicas", "2"}, []string{"pinata", "service"}, "https://file.example.com", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+			cfg, err := Load(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(cfg.Pinning.Providers, tt.wantProviders) {
+				t.Errorf("providers = %v, want %v", cfg.Pinning.Providers, tt.wantProviders)
+			}
+			var endpoint string
+			if len(cfg.Pinning.Services) > 0 {
+				endpoint = cfg.Pinning.Services[0].Endpoint
+			}
+			if endpoint != tt.wantEndpoint {
+				t.Errorf("service endpoint = %q, want %q", endpoint, tt.wantEndpoint)
+			}
+			if got := cfg.DefaultMinReplicas(len(cfg.Pinning.Providers)); got != tt.wantReplicas {
+				t.Errorf("min replicas = %d, want %d", got, tt.wantReplicas)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+	}{
+		{"unknown flag", nil, []string{"-nope"}},
+		{"missing file", nil, []string{"-config", filepath.Join(t.TempDir(), "missing.yaml")}},
+		{"unknown yaml field", nil, []string{"-config", writeConfig(t, "grpc:\n  port: 1\n")}},
+		{"bad env duration", map[string]string{"SHUTDOWN_TIMEOUT": "soon"}, nil},
+		{"bad env number", map[string]string{"MIN_REPLICAS": "two"}, nil},
+		{"invalid store", nil, []string{"-store", "sqlite"}},
+		{"none with other providers", map[string]string{"JWT": "token"}, []string{"-pinning-providers", "none,pinata"}},
+		{"bootstrap peer without id", map[string]string{"P2P_BOOTSTRAP_PEERS": "/ip4/10.0.0.1/tcp/4001"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+			if _, err := Load(tt.args); err == nil {
+				t.Fatal("Load succeeded")
+			}
+		})
+	}
+
+	setEnv(t, nil)
+	if _, err := Load([]string{"-h"}); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("Load(-h) = %v, want flag.ErrHelp", err)
+	}
+}
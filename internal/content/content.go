@@ -0,0 +1,90 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"spacecore_registry/internal/metrics"
+
+	"github.com/ipfs/boxo/files"
+	"github.com/ipfs/boxo/path"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/routing"
+)
+
+// Backend stores plugin content as UnixFS files and serves it back.
+type Backend interface {
+	// Add imports r as a UnixFS file and returns its path. The content is
+	// not pinned.
+	Add(ctx context.Context, r io.Reader) (path.ImmutablePath, error)
+	// AddNode stores an IPLD node, such as a block of the plugin index.
+	AddNode(ctx context.Context, nd ipld.Node) error
+	// Get returns the file at p, fetching it from the network if needed.
+	Get(ctx context.Context, p path.ImmutablePath) (files.File, error)
+	Pin(ctx context.Context, p path.ImmutablePath) error
+	Unpin(ctx context.Context, p path.ImmutablePath) error
+	IsPinned(ctx context.Context, p path.ImmutablePath) (bool, error)
+	// Provide announces on the DHT that the node serving this backend has
+	// the root of p.
+	Provide(ctx context.Context, p path.ImmutablePath) error
+	// Ping checks that the IPFS node can be reached.
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// Backend names accepted by Open.
+const (
+	BackendKubo     = "kubo"
+	BackendEmbedded = "embedded"
+)
+
+// Options configures the backend selected by Open.
+type Options struct {
+	Backend string
+	// APIAddr is the kubo RPC endpoint, as a multiaddr or http(s) URL. Empty
+	// uses the API file of the local IPFS repo.
+	APIAddr string
+	// Host is the registry's libp2p host, shared by the embedded node.
+	Host host.Host
+	// Routing finds and announces providers for the embedded node.
+	Routing routing.ContentRouting
+	// RepoPath is where the embedded node keeps its blocks and pins.
+	RepoPath string
+}
+
+// Open returns the Backend selected by opts.Backend. An empty backend
+// defaults to a local kubo daemon.
+func Open(ctx context.Context, opts Options) (Backend, error) {
+	var backend Backend
+	var err error
+	switch opts.Backend {
+	case "", BackendKubo:
+		backend, err = NewKubo(opts.APIAddr)
+	case BackendEmbedded:
+		backend, err = NewEmbedded(ctx, opts.Host, opts.Routing, opts.RepoPath)
+	default:
+		return nil, fmt.Errorf("unknown content backend %q", opts.Backend)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return instrumented{backend}, nil
+}
+
+// instrumented records how long adds and pins take.
+type instrumented struct {
+	Backend
+}
+
+func (b instrumented) Add(ctx context.Context, r io.Reader) (path.ImmutablePath, error) {
+	defer metrics.ObserveIPFS("add", time.Now())
+	return b.Backend.Add(ctx, r)
+}
+
+func (b instrumented) Pin(ctx context.Context, p path.ImmutablePath) error {
+	defer metrics.ObserveIPFS("pin", time.Now())
+	return b.Backend.Pin(ctx, p)
+}
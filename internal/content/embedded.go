@@ -0,0 +1,148 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ipfs/boxo/bitswap"
+	bsnet "github.com/ipfs/boxo/bitswap/network"
+	"github.com/ipfs/boxo/blockservice"
+	"github.com/ipfs/boxo/blockstore"
+	chunk "github.com/ipfs/boxo/chunker"
+	"github.com/ipfs/boxo/files"
+	"github.com/ipfs/boxo/ipld/merkledag"
+	unixfile "github.com/ipfs/boxo/ipld/unixfs/file"
+	"github.com/ipfs/boxo/ipld/unixfs/importer"
+	"github.com/ipfs/boxo/path"
+	pin "github.com/ipfs/boxo/pinning/pinner"
+	"github.com/ipfs/boxo/pinning/pinner/dspinner"
+	"github.com/ipfs/go-datastore"
+	leveldb "github.com/ipfs/go-ds-leveldb"
+	ipld "github.com/ipfs/go-ipld-format"
+	routinghelpers "github.com/libp2p/go-libp2p-routing-helpers"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/routing"
+)
+
+// Embedded is an in-process IPFS node: a leveldb-backed blockstore exchanged
+// over bitswap on the registry's own libp2p host. Files are chunked the same
+// way kubo does by default, so both backends produce the same CIDs.
+type Embedded struct {
+	datastore *leveldb.Datastore
+	bitswap   *bitswap.Bitswap
+	dag       ipld.DAGService
+	pinner    pin.Pinner
+	routing   routing.ContentRouting
+}
+
+// NewEmbedded opens or creates the node's repo at repoPath and starts
+// bitswap on h, finding providers through r.
+func NewEmbedded(ctx context.Context, h host.Host, r routing.ContentRouting, repoPath string) (*Embedded, error) {
+	if h == nil {
+		return nil, errors.New("embedded IPFS node requires a libp2p host")
+	}
+	ds, err := leveldb.NewDatastore(repoPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open IPFS repo %s: %w", repoPath, err)
+	}
+	bstore := blockstore.NewBlockstore(ds)
+	if r == nil {
+		// Without content routing, blocks are only fetched from connected peers.
+		r = routinghelpers.Null{}
+	}
+	network := bsnet.NewFromIpfsHost(h, r)
+	// Only plugin roots are announced, through Provide; announcing every
+	// block would flood the DHT.
+	bs := bitswap.New(ctx, network, bstore, bitswap.ProvideEnabled(false))
+	dag := merkledag.NewDAGService(blockservice.New(bstore, bs))
+	pinner, err := dspinner.New(ctx, ds, dag)
+	if err != nil {
+		bs.Close()
+		ds.Close()
+		return nil, fmt.Errorf("failed to load pins: %w", err)
+	}
+	return &Embedded{
+		datastore: ds,
+		bitswap:   bs,
+		dag:       dag,
+		pinner:    pinner,
+		routing:   r,
+	}, nil
+}
+
+func (e *Embedded) Add(ctx context.Context, r io.Reader) (path.ImmutablePath, error) {
+	nd, err := importer.BuildDagFromReader(e.dag, chunk.DefaultSplitter(r))
+	if err != nil {
+		return path.ImmutablePath{}, err
+	}
+	return path.FromCid(nd.Cid()), nil
+}
+
+func (e *Embedded) AddNode(ctx context.Context, nd ipld.Node) error {
+	return e.dag.Add(ctx, nd)
+}
+
+func (e *Embedded) Get(ctx context.Context, p path.ImmutablePath) (files.File, error) {
+	nd, err := e.node(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	node, err := unixfile.NewUnixfsFile(ctx, e.dag, nd)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := node.(files.File)
+	if !ok {
+		node.Close()
+		return nil, fmt.Errorf("%s is not a file", p)
+	}
+	return f, nil
+}
+
+func (e *Embedded) Pin(ctx context.Context, p path.ImmutablePath) error {
+	nd, err := e.node(ctx, p)
+	if err != nil {
+		return err
+	}
+	if err := e.pinner.Pin(ctx, nd, true, ""); err != nil {
+		return err
+	}
+	return e.pinner.Flush(ctx)
+}
+
+func (e *Embedded) Unpin(ctx context.Context, p path.ImmutablePath) error {
+	if err := e.pinner.Unpin(ctx, p.RootCid(), true); err != nil {
+		return err
+	}
+	return e.pinner.Flush(ctx)
+}
+
+func (e *Embedded) IsPinned(ctx context.Context, p path.ImmutablePath) (bool, error) {
+	_, pinned, err := e.pinner.IsPinned(ctx, p.RootCid())
+	return pinned, err
+}
+
+func (e *Embedded) Provide(ctx context.Context, p path.ImmutablePath) error {
+	return e.routing.Provide(ctx, p.RootCid(), true)
+}
+
+// Ping reads from the repo, which fails once it has been closed.
+func (e *Embedded) Ping(ctx context.Context) error {
+	_, err := e.datastore.Has(ctx, datastore.NewKey("/ping"))
+	return err
+}
+
+func (e *Embedded) Close() error {
+	return errors.Join(e.bitswap.Close(), e.datastore.Close())
+}
+
+// node loads the root of p. Plugins are single files, so paths into a
+// directory are not supported.
+func (e *Embedded) node(ctx context.Context, p path.ImmutablePath) (ipld.Node, error) {
+	if len(p.Segments()) > 2 {
+		return nil, fmt.Errorf("path %s: only root CIDs are supported", p)
+	}
+	return e.dag.Get(ctx, p.RootCid())
+}
@@ -0,0 +1,92 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ipfs/boxo/files"
+	"github.com/ipfs/boxo/path"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ipfs/kubo/client/rpc"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// Kubo stores content through the HTTP RPC API of a kubo daemon.
+type Kubo struct {
+	api *rpc.HttpApi
+}
+
+// NewKubo connects to the kubo daemon at addr, a multiaddr or http(s) URL,
+// or to the one found in the local IPFS repo when addr is empty.
+func NewKubo(addr string) (*Kubo, error) {
+	var api *rpc.HttpApi
+	var err error
+	switch {
+	case addr == "":
+		api, err = rpc.NewLocalApi()
+	case strings.HasPrefix(addr, "/"):
+		var maddr ma.Multiaddr
+		if maddr, err = ma.NewMultiaddr(addr); err == nil {
+			api, err = rpc.NewApi(maddr)
+		}
+	default:
+		api, err = rpc.NewURLApiWithClient(addr, http.DefaultClient)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create IPFS client: %w", err)
+	}
+	return &Kubo{api: api}, nil
+}
+
+func (k *Kubo) Add(ctx context.Context, r io.Reader) (path.ImmutablePath, error) {
+	return k.api.Unixfs().Add(ctx, files.NewReaderFile(r))
+}
+
+func (k *Kubo) AddNode(ctx context.Context, nd ipld.Node) error {
+	return k.api.Dag().Add(ctx, nd)
+}
+
+func (k *Kubo) Get(ctx context.Context, p path.ImmutablePath) (files.File, error) {
+	node, err := k.api.Unixfs().Get(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := node.(files.File)
+	if !ok {
+		node.Close()
+		return nil, fmt.Errorf("failed to convert fileNode type %T to files.File", node)
+	}
+	return f, nil
+}
+
+func (k *Kubo) Pin(ctx context.Context, p path.ImmutablePath) error {
+	return k.api.Pin().Add(ctx, p)
+}
+
+func (k *Kubo) Unpin(ctx context.Context, p path.ImmutablePath) error {
+	return k.api.Pin().Rm(ctx, p)
+}
+
+func (k *Kubo) IsPinned(ctx context.Context, p path.ImmutablePath) (bool, error) {
+	_, pinned, err := k.api.Pin().IsPinned(ctx, p)
+	return pinned, err
+}
+
+// Provide asks the daemon to announce p, since it is the peer that serves
+// the content.
+func (k *Kubo) Provide(ctx context.Context, p path.ImmutablePath) error {
+	return k.api.Routing().Provide(ctx, p)
+}
+
+// Ping asks the daemon for its version.
+func (k *Kubo) Ping(ctx context.Context) error {
+	return k.api.Request("version").Exec(ctx, nil)
+}
+
+// Close is a no-op; the daemon outlives the registry.
+func (k *Kubo) Close() error {
+	return nil
+}
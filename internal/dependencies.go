@@ -0,0 +1,293 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"spacecore_registry/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxResolveSteps bounds the backtracking search; dependency graphs that
+// need more than this are reported rather than searched exhaustively.
+const maxResolveSteps = 10000
+
+// checkDependencies verifies that every dependency in the manifest of name
+// has a matching version registered.
+func (s *pluginRegistryServer) checkDependencies(ctx context.Context, name string, manifest *pb.PluginManifest) error {
+	for _, dep := range manifest.GetDependencies() {
+		if dep.Name == name {
+			return status.Errorf(codes.InvalidArgument, "%s cannot depend on itself", name)
+		}
+		if _, err := s.resolvePlugin(ctx, dep.Name, dep.Version); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return status.Errorf(codes.FailedPrecondition, "unsatisfied dependency: %v", status.Convert(err).Message())
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *pluginRegistryServer) ResolveDependencies(ctx context.Context, req *pb.ResolveDependenciesRequest) (*pb.ResolveDependenciesResponse, error) {
+	if len(req.Requirements) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one requirement is needed")
+	}
+	r := &resolver{
+		server:   s,
+		os:       req.Os,
+		arch:     req.Arch,
+		versions: make(map[string][]string),
+		plugins:  make(map[string]*pb.Plugin),
+	}
+	root := resolution{assigned: make(map[string]*pb.Plugin), reqs: make(map[string][]requirement)}
+	for _, dep := range req.Requirements {
+		if err := validatePluginName(dep.Name); err != nil {
+			return nil, err
+		}
+		if _, err := matchVersions(nil, dep.Version); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid version %q for %s: %v", dep.Version, dep.Name, err)
+		}
+		root.require(dep.Name, requirement{spec: dep.Version})
+	}
+
+	assigned, err := r.solve(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	requiredBy := make(map[string][]string)
+	for _, plugin := range assigned {
+		for _, dep := range plugin.GetManifest().GetDependencies() {
+			requiredBy[dep.Name] = append(requiredBy[dep.Name], plugin.Name+"@"+plugin.Version)
+		}
+	}
+	resp := &pb.ResolveDependenciesResponse{}
+	for name, plugin := range assigned {
+		cid := plugin.Cid
+		if r.os != "" || r.arch != "" {
+			artifact, err := selectArtifact(plugin, r.os, r.arch)
+			if err != nil {
+				return nil, err
+			}
+			cid = artifact.Cid
+		}
+		sort.Strings(requiredBy[name])
+		resp.Plugins = append(resp.Plugins, &pb.ResolvedPlugin{
+			Name:       name,
+			Version:    plugin.Version,
+			Cid:        cid,
+			RequiredBy: requiredBy[name],
+		})
+	}
+	sort.Slice(resp.Plugins, func(i, j int) bool { return resp.Plugins[i].Name < resp.Plugins[j].Name })
+	return resp, nil
+}
+
+// requirement is a version range for a plugin and where it came from.
+type requirement struct {
+	spec string
+	// by is the dependent as name@version, empty for a root requirement.
+	by string
+}
+
+func (r requirement) String() string {
+	spec := r.spec
+	if spec == "" {
+		spec = latestVersion
+	}
+	if r.by == "" {
+		return spec + " (requested)"
+	}
+	return spec + " (required by " + r.by + ")"
+}
+
+// resolution is one partial assignment explored by the resolver.
+type resolution struct {
+	assigned map[string]*pb.Plugin
+	reqs     map[string][]requirement
+	// order lists names in the order they were first required, so the
+	// search is deterministic.
+	order []string
+}
+
+func (st *resolution) require(name string, req requirement) {
+	if _, ok := st.reqs[name]; !ok {
+		st.order = append(st.order, name)
+	}
+	st.reqs[name] = append(st.reqs[name], req)
+}
+
+// with returns a copy of st with plugin chosen and its dependencies
+// required, or an error if one of them rules out a version already chosen.
+func (st resolution) with(plugin *pb.Plugin) (resolution, error) {
+	next := resolution{
+		assigned: make(map[string]*pb.Plugin, len(st.assigned)+1),
+		reqs:     make(map[string][]requirement, len(st.reqs)),
+		order:    slices.Clone(st.order),
+	}
+	for name, p := range st.assigned {
+		next.assigned[name] = p
+	}
+	for name, reqs := range st.reqs {
+		next.reqs[name] = slices.Clone(reqs)
+	}
+	next.assigned[plugin.Name] = plugin
+
+	by := plugin.Name + "@" + plugin.Version
+	for _, dep := range plugin.GetManifest().GetDependencies() {
+		req := requirement{spec: dep.Version, by: by}
+		if chosen, ok := next.assigned[dep.Name]; ok {
+			matches, err := matchVersions([]string{chosen.Version}, dep.Version)
+			if err != nil || len(matches) == 0 {
+				return resolution{}, status.Errorf(codes.FailedPrecondition, "%s needs %s %s, but %s@%s was chosen for %s",
+					by, dep.Name, req.spec, dep.Name, chosen.Version, joinRequirements(next.reqs[dep.Name]))
+			}
+		}
+		next.require(dep.Name, req)
+	}
+	return next, nil
+}
+
+func joinRequirements(reqs []requirement) string {
+	parts := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		parts = append(parts, req.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// resolver searches for a version of every required plugin that satisfies
+// all requirements on it, preferring the highest versions and backtracking
+// on conflicts. Store lookups are cached for the duration of one request.
+type resolver struct {
+	server   *pluginRegistryServer
+	os, arch string
+	versions map[string][]string
+	plugins  map[string]*pb.Plugin
+	steps    int
+}
+
+func (r *resolver) solve(ctx context.Context, st resolution) (map[string]*pb.Plugin, error) {
+	var name string
+	for _, n := range st.order {
+		if _, ok := st.assigned[n]; !ok {
+			name = n
+			break
+		}
+	}
+	if name == "" {
+		return st.assigned, nil
+	}
+
+	candidates, err := r.candidates(ctx, name, st.reqs[name])
+	if err != nil {
+		return nil, err
+	}
+	// Report the conflict met with the most preferred candidate; it is the
+	// one the caller most likely expected.
+	var first error
+	for _, plugin := range candidates {
+		r.steps++
+		if r.steps > maxResolveSteps {
+			return nil, status.Errorf(codes.ResourceExhausted, "gave up resolving dependencies after %d steps", maxResolveSteps)
+		}
+		next, err := st.with(plugin)
+		if err == nil {
+			var assigned map[string]*pb.Plugin
+			assigned, err = r.solve(ctx, next)
+			if err == nil {
+				return assigned, nil
+			}
+		}
+		if status.Code(err) != codes.FailedPrecondition {
+			return nil, err
+		}
+		if first == nil {
+			first = err
+		}
+	}
+	if first != nil {
+		return nil, first
+	}
+	return nil, r.unsatisfiable(name, st.reqs[name])
+}
+
+// candidates returns the versions of name that satisfy every requirement,
+// highest first. Yanked versions are only considered when pinned exactly.
+func (r *resolver) candidates(ctx context.Context, name string, reqs []requirement) ([]*pb.Plugin, error) {
+	versions, ok := r.versions[name]
+	if !ok {
+		var err error
+		versions, err = r.server.store.ListVersions(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		r.versions[name] = versions
+	}
+
+	matching := versions
+	exact := false
+	for _, req := range reqs {
+		matches, err := matchVersions(versions, req.spec)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid version %q for %s: %v", req.spec, name, err)
+		}
+		exact = exact || isExactVersion(req.spec)
+		matching = intersect(matches, matching)
+	}
+
+	var candidates []*pb.Plugin
+	for _, version := range matching {
+		key := name + "@" + version
+		plugin, ok := r.plugins[key]
+		if !ok {
+			var err error
+			plugin, err = r.server.store.GetPlugin(ctx, name, version)
+			if err != nil {
+				return nil, storeError(err)
+			}
+			r.plugins[key] = plugin
+		}
+		if plugin.Yanked && !exact {
+			continue
+		}
+		if r.os != "" || r.arch != "" {
+			if _, err := selectArtifact(plugin, r.os, r.arch); err != nil {
+				continue
+			}
+		}
+		candidates = append(candidates, plugin)
+	}
+	return candidates, nil
+}
+
+// intersect keeps the entries of a, in order, that also appear in b.
+func intersect(a, b []string) []string {
+	var out []string
+	for _, v := range a {
+		if slices.Contains(b, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// unsatisfiable explains why no version of name can be chosen.
+func (r *resolver) unsatisfiable(name string, reqs []requirement) error {
+	versions := r.versions[name]
+	if len(versions) == 0 {
+		return status.Errorf(codes.FailedPrecondition, "%s is not registered, but is needed for %s", name, joinRequirements(reqs))
+	}
+	platform := ""
+	if r.os != "" || r.arch != "" {
+		platform = fmt.Sprintf(" with a %s build", platformString(&pb.Platform{Os: r.os, Arch: r.arch}))
+	}
+	return status.Errorf(codes.FailedPrecondition, "no version of %s%s satisfies %s (available: %s)",
+		name, platform, joinRequirements(reqs), strings.Join(versions, ", "))
+}
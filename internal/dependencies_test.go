@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"spacecore_registry/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// depPlugin returns a plugin record whose manifest declares deps, given as
+// alternating names and version ranges.
+func depPlugin(name, version string, deps ...string) *pb.Plugin {
+	p := &pb.Plugin{Name: name, Version: version, Cid: "/ipfs/" + name + "-" + version, Manifest: &pb.PluginManifest{}}
+	for i := 0; i+1 < len(deps); i += 2 {
+		p.Manifest.Dependencies = append(p.Manifest.Dependencies, &pb.Dependency{Name: deps[i], Version: deps[i+1]})
+	}
+	return p
+}
+
+func TestResolveDependencies(t *testing.T) {
+	s := newTestServer(t,
+		depPlugin("lib", "1.0.0"),
+		depPlugin("lib", "1.1.0"),
+		depPlugin("lib", "2.0.0"),
+		depPlugin("app", "1.0.0", "lib", "^1"),
+		depPlugin("app", "2.0.0", "lib", "^2"),
+		depPlugin("tool", "1.0.0", "lib", "~1.0"),
+		depPlugin("cli", "1.0.0", "lib", "^2"),
+		depPlugin("broken", "1.0.0", "missing", "^1"),
+	)
+	tests := []struct {
+		name     string
+		reqs     []string
+		want     []string
+		wantCode codes.Code
+		// wantErr is a substring the conflict message must name.
+		wantErr string
+	}{
+		{"latest", []string{"app", ""}, []string{"app@2.0.0", "lib@2.0.0"}, codes.OK, ""},
+		{"backtracks to an older dependent", []string{"app", "", "lib", "^1"}, []string{"app@1.0.0", "lib@1.1.0"}, codes.OK, ""},
+		{"shared dependency narrowed", []string{"app", "^1", "tool", ""}, []string{"app@1.0.0", "lib@1.0.0", "tool@1.0.0"}, codes.OK, ""},
+		{"conflicting dependents", []string{"tool", "", "cli", ""}, nil, codes.FailedPrecondition, "lib"},
+		{"conflict with a request", []string{"cli", "", "lib", "^1"}, nil, codes.FailedPrecondition, "lib"},
+		{"missing dependency", []string{"broken", ""}, nil, codes.FailedPrecondition, "missing is not registered"},
+		{"no matching version", []string{"lib", "^3"}, nil, codes.FailedPrecondition, "no version of lib"},
+		{"invalid range", []string{"lib", "not a range!"}, nil, codes.InvalidArgument, ""},
+		{"no requirements", nil, nil, codes.InvalidArgument, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pb.ResolveDependenciesRequest{}
+			for i := 0; i+1 < len(tt.reqs); i += 2 {
+				req.Requirements = append(req.Requirements, &pb.Dependency{Name: tt.reqs[i], Version: tt.reqs[i+1]})
+			}
+			resp, err := s.ResolveDependencies(context.Background(), req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("error = %v, want code %s", err, tt.wantCode)
+			}
+			if err != nil {
+				if !strings.Contains(status.Convert(err).Message(), tt.wantErr) {
+					t.Errorf("error %q does not mention %q", status.Convert(err).Message(), tt.wantErr)
+				}
+				return
+			}
+			var got []string
+			for _, p := range resp.Plugins {
+				got = append(got, p.Name+"@"+p.Version)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("resolved %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+
+	"spacecore_registry/internal/metrics"
+
+	"github.com/ipfs/boxo/path"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// digester hashes and counts everything written to it.
+type digester struct {
+	hash hash.Hash
+	size int64
+}
+
+func newDigester() *digester {
+	return &digester{hash: sha256.New()}
+}
+
+func (d *digester) Write(p []byte) (int, error) {
+	d.size += int64(len(p))
+	return d.hash.Write(p)
+}
+
+func (d *digester) Sum() string {
+	return hex.EncodeToString(d.hash.Sum(nil))
+}
+
+// addContent adds r to the content backend and returns its path along with
+// the SHA-256 and size of the bytes read. Content larger than the server's
+// maxUploadSize is rejected with ResourceExhausted.
+func (s *pluginRegistryServer) addContent(ctx context.Context, r io.Reader) (path.ImmutablePath, string, int64, error) {
+	d := newDigester()
+	limited := &sizeLimiter{r: r, limit: s.maxUploadSize}
+	p, err := s.content.Add(ctx, io.TeeReader(limited, d))
+	metrics.UploadedBytes.Add(float64(d.size))
+	if limited.exceeded {
+		return path.ImmutablePath{}, "", 0, status.Errorf(codes.ResourceExhausted, "plugin is larger than the %d byte upload limit", s.maxUploadSize)
+	}
+	if err != nil {
+		return path.ImmutablePath{}, "", 0, err
+	}
+	return p, d.Sum(), d.size, nil
+}
+
+// sizeLimiter fails reads once more than limit bytes have been read. A zero
+// limit reads everything.
+type sizeLimiter struct {
+	r        io.Reader
+	limit    int64
+	read     int64
+	exceeded bool
+}
+
+func (l *sizeLimiter) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.limit > 0 && l.read > l.limit {
+		l.exceeded = true
+		return 0, errUploadTooLarge
+	}
+	return n, err
+}
+
+var errUploadTooLarge = errors.New("upload limit exceeded")
+
+// verifyDigest checks streamed content against what was recorded at
+// register time.
+func verifyDigest(d *digester, wantSHA256 string, wantSize int64) error {
+	if d.size != wantSize {
+		return status.Errorf(codes.DataLoss, "content is %d bytes, expected %d", d.size, wantSize)
+	}
+	if got := d.Sum(); got != wantSHA256 {
+		return status.Errorf(codes.DataLoss, "content has SHA-256 %s, expected %s", got, wantSHA256)
+	}
+	return nil
+}
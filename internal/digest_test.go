@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"testing"
+
+	"spacecore_registry/pb"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDownloadVerifiesDigest(t *testing.T) {
+	key := newTestKey(t, crypto.Ed25519)
+	data := make([]byte, 2*downloadChunkSize+100)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	sum := sha256.Sum256(data)
+	c := testCID(t, string(data))
+
+	tests := []struct {
+		name     string
+		stored   func([]byte) []byte
+		offset   int64
+		wantCode codes.Code
+	}{
+		{"intact", nil, 0, codes.OK},
+		{"intact resumed", nil, downloadChunkSize + 7, codes.OK},
+		{"tampered", func(b []byte) []byte { b[len(b)-1]++; return b }, 0, codes.DataLoss},
+		{"tampered before the offset", func(b []byte) []byte { b[0]++; return b }, downloadChunkSize, codes.DataLoss},
+		{"truncated", func(b []byte) []byte { return b[:len(b)-1] }, 0, codes.DataLoss},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mem := newContentServer(t)
+			if _, err := upload(t, s, key, "ns/a", "1.0.0", data, 64<<10); err != nil {
+				t.Fatal(err)
+			}
+			if tt.stored != nil {
+				mem.put(c, tt.stored(slices.Clone(data)))
+			}
+			frames, content, err := download(s, &pb.DownloadPluginRequest{Cid: "/ipfs/" + c, Offset: tt.offset})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("error = %v, want code %s", err, tt.wantCode)
+			}
+			if tt.wantCode != codes.OK {
+				// The end of a binary that failed to verify is never sent.
+				if int64(len(content)) >= int64(len(data))-tt.offset {
+					t.Errorf("sent all %d bytes of content that failed to verify", len(content))
+				}
+				return
+			}
+			if frames[0].Sha256 != hex.EncodeToString(sum[:]) {
+				t.Errorf("first frame sha256 = %q", frames[0].Sha256)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"spacecore_registry/internal/p2p"
+	"spacecore_registry/internal/store"
+	"spacecore_registry/pb"
+
+	"github.com/Masterminds/semver/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// federationInterval is how often the registry re-advertises itself and
+// refreshes the indexes of federated peers.
+const federationInterval = 10 * time.Minute
+
+// federationIndex serves the plugins registered here, without local pinning
+// state, to federated peers. Records learned from other registries are not
+// passed on.
+func federationIndex(metadataStore store.MetadataStore) p2p.IndexFunc {
+	return func(ctx context.Context) ([]*pb.Plugin, error) {
+		plugins, err := metadataStore.ListPlugins(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i, plugin := range plugins {
+			plugins[i] = publicRecord(plugin)
+		}
+		return plugins, nil
+	}
+}
+
+// publicRecord returns a copy of plugin without local pinning state, for
+// sharing with other registries.
+func publicRecord(plugin *pb.Plugin) *pb.Plugin {
+	public := proto.Clone(plugin).(*pb.Plugin)
+	public.Pins = nil
+	return public
+}
+
+// announce tells peer registries about a change to plugin. Failures are only
+// logged: peers still pick the change up from the next index fetch.
+func (s *pluginRegistryServer) announce(ctx context.Context, kind pb.PluginAnnouncement_Kind, plugin *pb.Plugin) {
+	if s.announcer == nil {
+		return
+	}
+	if err := s.announcer.Announce(ctx, kind, publicRecord(plugin)); err != nil {
+		log.Printf("failed to announce %s:%s: %v", plugin.Name, plugin.Version, err)
+	}
+}
+
+// verifyPluginRecord checks the publisher signatures carried by a plugin
+// record received from another registry: every artifact must be signed for
+// its CID and platforms and the version's manifest, the top-level CID must
+// be one of them, and the version's platforms must be exactly those of its
+// artifacts.
+func verifyPluginRecord(plugin *pb.Plugin) error {
+	if err := validatePluginName(plugin.Name); err != nil {
+		return err
+	}
+	// Publishers sign the manifest as they sent it, and the record must
+	// carry its normalized form.
+	signed := signedManifest(plugin)
+	normalized, err := normalizedManifest(signed)
+	if err != nil {
+		return err
+	}
+	if !proto.Equal(normalized, plugin.Manifest) {
+		return fmt.Errorf("manifest of %s:%s is not the normalized form of the signed one", plugin.Name, plugin.Version)
+	}
+	manifest, err := manifestDigest(signed)
+	if err != nil {
+		return err
+	}
+	p, err := pluginPath(plugin)
+	if err != nil {
+		return fmt.Errorf("invalid cid %q: %w", plugin.Cid, err)
+	}
+	var platforms []*pb.Platform
+	for _, artifact := range artifactsOf(plugin) {
+		if err := verifyArtifact(plugin, manifest, artifact); err != nil {
+			return err
+		}
+		platforms = mergePlatforms(platforms, artifact.Platforms)
+	}
+	top := findArtifact(plugin, p.RootCid().String())
+	if top == nil || top.Publisher != plugin.Publisher || !bytes.Equal(top.Signature, plugin.Signature) || !bytes.Equal(top.PublicKey, plugin.PublicKey) {
+		return fmt.Errorf("cid %s is not a signed artifact of %s:%s", plugin.Cid, plugin.Name, plugin.Version)
+	}
+	if !samePlatforms(platforms, plugin.Platforms) {
+		return fmt.Errorf("platforms %s of %s:%s don't match its signed artifacts", platformList(plugin.Platforms), plugin.Name, plugin.Version)
+	}
+	return nil
+}
+
+// verifyArtifact checks the publisher signature on one artifact of plugin,
+// whose manifest has the given manifestDigest.
+func verifyArtifact(plugin *pb.Plugin, manifest string, artifact *pb.Artifact) error {
+	p, err := cidPath(artifact.Cid)
+	if err != nil {
+		return fmt.Errorf("invalid artifact cid %q: %w", artifact.Cid, err)
+	}
+	payload := pluginSignaturePayload(p.RootCid().String(), plugin.Name, plugin.Version, artifact.Platforms, manifest)
+	publisher, err := verifySignature(artifact.PublicKey, artifact.Signature, payload)
+	if err != nil {
+		return err
+	}
+	if publisher.String() != artifact.Publisher {
+		return fmt.Errorf("artifact %s signed by %s, not publisher %s", artifact.Cid, publisher, artifact.Publisher)
+	}
+	return nil
+}
+
+// federatedPlugins resolves spec against each federated registry's versions
+// of name and returns the best match from each, following the same rules as
+// resolvePlugin.
+func (s *pluginRegistryServer) federatedPlugins(name, spec string) ([]*pb.Plugin, error) {
+	if s.federation == nil {
+		return nil, nil
+	}
+	byRegistry := make(map[string]map[string]*pb.Plugin)
+	for _, plugin := range s.federation.Plugins() {
+		if plugin.Name != name {
+			continue
+		}
+		if byRegistry[plugin.Registry] == nil {
+			byRegistry[plugin.Registry] = make(map[string]*pb.Plugin)
+		}
+		byRegistry[plugin.Registry][plugin.Version] = plugin
+	}
+
+	exact := isExactVersion(spec)
+	var matches []*pb.Plugin
+	for _, records := range byRegistry {
+		var versions []string
+		for version := range records {
+			if _, err := semver.NewVersion(version); err == nil {
+				versions = append(versions, version)
+			}
+		}
+		sort.Slice(versions, func(i, j int) bool {
+			return semver.MustParse(versions[i]).LessThan(semver.MustParse(versions[j]))
+		})
+		candidates, err := matchVersions(versions, spec)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid version %q: %v", spec, err)
+		}
+		for _, version := range candidates {
+			if plugin := records[version]; exact || !plugin.Yanked {
+				matches = append(matches, plugin)
+				break
+			}
+		}
+	}
+	sortFederated(matches)
+	return matches, nil
+}
+
+// sortFederated orders federated records by name, registry and semver
+// precedence so responses are stable.
+func sortFederated(plugins []*pb.Plugin) {
+	sort.Slice(plugins, func(i, j int) bool {
+		a, b := plugins[i], plugins[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Registry != b.Registry {
+			return a.Registry < b.Registry
+		}
+		return store.VersionLess(a.Version, b.Version)
+	})
+}
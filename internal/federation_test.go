@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"testing"
+
+	"spacecore_registry/pb"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/multiformats/go-multihash"
+	"google.golang.org/protobuf/proto"
+)
+
+func testCID(t *testing.T, data string) string {
+	t.Helper()
+	c, err := cid.Prefix{Version: 1, Codec: cid.Raw, MhType: multihash.SHA2_256, MhLength: -1}.Sum([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c.String()
+}
+
+// signedArtifact returns an artifact of plugin built for the given
+// platforms and signed by key.
+func signedArtifact(t *testing.T, key testKey, plugin *pb.Plugin, data string, platforms []*pb.Platform) *pb.Artifact {
+	t.Helper()
+	manifest, err := manifestDigest(signedManifest(plugin))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := testCID(t, data)
+	return &pb.Artifact{
+		Cid:       "/ipfs/" + c,
+		Platforms: platforms,
+		Publisher: key.id.String(),
+		PublicKey: key.pub,
+		Signature: key.sign(t, pluginSignaturePayload(c, plugin.Name, plugin.Version, platforms, manifest)),
+	}
+}
+
+// signedRecord returns a plugin record with one signed artifact per
+// platform list, as a federated registry would serve it.
+func signedRecord(t *testing.T, key testKey, name, version string, builds ...[]*pb.Platform) *pb.Plugin {
+	t.Helper()
+	return signedManifestRecord(t, key, name, version, nil, builds...)
+}
+
+// signedManifestRecord is signedRecord for a version with the given
+// manifest, stored the way the registry stores what a publisher sent.
+func signedManifestRecord(t *testing.T, key testKey, name, version string, sent *pb.PluginManifest, builds ...[]*pb.Platform) *pb.Plugin {
+	t.Helper()
+	manifest, err := normalizedManifest(sent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plugin := &pb.Plugin{Name: name, Version: version, Manifest: manifest}
+	if !proto.Equal(manifest, sent) {
+		plugin.SignedManifest = sent
+	}
+	for i, platforms := range builds {
+		artifact := signedArtifact(t, key, plugin, name+version+string(rune('a'+i)), platforms)
+		plugin.Artifacts = append(plugin.Artifacts, artifact)
+		plugin.Platforms = mergePlatforms(plugin.Platforms, platforms)
+	}
+	top := plugin.Artifacts[0]
+	plugin.Cid, plugin.Publisher, plugin.PublicKey, plugin.Signature = top.Cid, top.Publisher, top.PublicKey, top.Signature
+	return plugin
+}
+
+func TestVerifyPluginRecord(t *testing.T) {
+	key := newTestKey(t, crypto.Ed25519)
+	other := newTestKey(t, crypto.Ed25519)
+
+	tests := []struct {
+		name    string
+		record  func() *pb.Plugin
+		wantErr bool
+	}{
+		{"single build", func() *pb.Plugin { return signedRecord(t, key, "acme/tool", "1.0.0", nil) }, false},
+		{"several builds", func() *pb.Plugin {
+			return signedRecord(t, key, "acme/tool", "1.0.0", platforms("linux/amd64"), platforms("darwin"))
+		}, false},
+		{"legacy record without artifacts", func() *pb.Plugin {
+			p := signedRecord(t, key, "acme/tool", "1.0.0", nil)
+			p.Artifacts = nil
+			return p
+		}, false},
+		{"version changed", func() *pb.Plugin {
+			p := signedRecord(t, key, "acme/tool", "1.0.0", nil)
+			p.Version = "1.0.1"
+			return p
+		}, true},
+		{"name changed", func() *pb.Plugin {
+			p := signedRecord(t, key, "acme/tool", "1.0.0", nil)
+			p.Name = "acme/other"
+			return p
+		}, true},
+		{"invalid name", func() *pb.Plugin { return signedRecord(t, key, "Not A Name", "1.0.0", nil) }, true},
+		{"publisher swapped", func() *pb.Plugin {
+			p := signedRecord(t, key, "acme/tool", "1.0.0", nil)
+			p.Artifacts[0].Publisher = other.id.String()
+			return p
+		}, true},
+		{"artifact platforms widened", func() *pb.Plugin {
+			p := signedRecord(t, key, "acme/tool", "1.0.0", platforms("linux/amd64"))
+			p.Artifacts[0].Platforms = platforms("linux")
+			p.Platforms = platforms("linux")
+			return p
+		}, true},
+		{"unsigned platform listed", func() *pb.Plugin {
+			p := signedRecord(t, key, "acme/tool", "1.0.0", platforms("linux/amd64"))
+			p.Platforms = platforms("linux/amd64", "windows/amd64")
+			return p
+		}, true},
+		{"top-level cid not an artifact", func() *pb.Plugin {
+			p := signedRecord(t, key, "acme/tool", "1.0.0", platforms("linux/amd64"))
+			p.Cid = "/ipfs/" + testCID(t, "elsewhere")
+			return p
+		}, true},
+		{"normalized manifest", func() *pb.Plugin {
+			return signedManifestRecord(t, key, "acme/tool", "1.0.0", &pb.PluginManifest{Permissions: []string{"network"}}, nil)
+		}, false},
+		{"manifest normalized by the registry", func() *pb.Plugin {
+			return signedManifestRecord(t, key, "acme/tool", "1.0.0", &pb.PluginManifest{Permissions: []string{" Network "}}, nil)
+		}, false},
+		{"manifest permissions added", func() *pb.Plugin {
+			p := signedManifestRecord(t, key, "acme/tool", "1.0.0", &pb.PluginManifest{Permissions: []string{"network"}}, nil)
+			p.Manifest.Permissions = append(p.Manifest.Permissions, "fs:write")
+			return p
+		}, true},
+		{"signed manifest replaced", func() *pb.Plugin {
+			p := signedManifestRecord(t, key, "acme/tool", "1.0.0", &pb.PluginManifest{Permissions: []string{" Network "}}, nil)
+			p.SignedManifest = &pb.PluginManifest{Permissions: []string{"network", "fs:write"}}
+			p.Manifest = &pb.PluginManifest{Permissions: []string{"network", "fs:write"}}
+			return p
+		}, true},
+		{"unnormalized manifest without the signed one", func() *pb.Plugin {
+			p := signedManifestRecord(t, key, "acme/tool", "1.0.0", &pb.PluginManifest{Permissions: []string{" Network "}}, nil)
+			p.Manifest, p.SignedManifest = p.SignedManifest, nil
+			return p
+		}, true},
+		{"top-level signature from another artifact", func() *pb.Plugin {
+			p := signedRecord(t, key, "acme/tool", "1.0.0", platforms("linux/amd64"), platforms("darwin"))
+			p.Signature = p.Artifacts[1].Signature
+			return p
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyPluginRecord(tt.record())
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Errorf("verifyPluginRecord() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSortFederated(t *testing.T) {
+	plugins := []*pb.Plugin{
+		{Name: "b", Registry: "peer1", Version: "1.0.0"},
+		{Name: "a", Registry: "peer2", Version: "1.0.0"},
+		{Name: "a", Registry: "peer1", Version: "1.10.0"},
+		{Name: "a", Registry: "peer1", Version: "1.9.0"},
+		{Name: "a", Registry: "peer1", Version: "1.10.0-rc.1"},
+	}
+	sortFederated(plugins)
+	want := []string{"a peer1 1.9.0", "a peer1 1.10.0-rc.1", "a peer1 1.10.0", "a peer2 1.0.0", "b peer1 1.0.0"}
+	for i, p := range plugins {
+		if got := p.Name + " " + p.Registry + " " + p.Version; got != want[i] {
+			t.Errorf("plugins[%d] = %s, want %s", i, got, want[i])
+		}
+	}
+}
@@ -2,86 +2,207 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"time"
 
+	"spacecore_registry/internal/config"
+	"spacecore_registry/internal/content"
+	"spacecore_registry/internal/health"
+	"spacecore_registry/internal/lifecycle"
+	"spacecore_registry/internal/metrics"
+	"spacecore_registry/internal/p2p"
+	"spacecore_registry/internal/pinning"
 	"spacecore_registry/pb"
 
-	"github.com/ipfs/kubo/client/rpc"
-	"github.com/libp2p/go-libp2p"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
-	kbucket "github.com/libp2p/go-libp2p-kbucket"
 	"github.com/libp2p/go-libp2p/core/host"
-	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
-// *kbucket.RoutingTable
+// Start runs the registry until ctx is cancelled or a component fails.
+// Components stop in the reverse of the order they started, so in-flight
+// RPCs drain before pending pin jobs are flushed and the stores, DHT and
+// host are closed.
+func Start(ctx context.Context, cfg *config.Config) error {
+	m := lifecycle.New()
+	var h host.Host
+	var kadDHT *dht.IpfsDHT
+	var server *pluginRegistryServer
+	var checker *health.Checker
+	healthServer := grpchealth.NewServer()
 
-func SetupHost() (host.Host, *kbucket.RoutingTable, error) {
-	ctx := context.Background()
-	h, err := libp2p.New(
-		libp2p.ListenAddrStrings("/ip4/0.0.0.0/tcp/0"),
-	)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create libp2p host: %w", err)
+	m.Add("libp2p host", func(ctx context.Context) error {
+		var err error
+		key, err := p2p.LoadIdentity(cfg.P2P.IdentityPath)
+		if err != nil {
+			return err
+		}
+		if h, err = p2p.NewHost(cfg.P2P.ListenAddrs, key); err != nil {
+			return err
+		}
+		log.Printf("Peer ID: %v", h.ID())
+		return nil
+	}, func(context.Context) error {
+		return h.Close()
+	})
+	m.Add("DHT", func(ctx context.Context) error {
+		var err error
+		if kadDHT, err = p2p.NewDHT(ctx, h, cfg.P2P.BootstrapPeers); err != nil {
+			return err
+		}
+		metrics.RegisterNetwork(h, kadDHT)
+		return nil
+	}, func(context.Context) error {
+		return kadDHT.Close()
+	})
+	m.Add("registry", func(ctx context.Context) error {
+		var err error
+		if server, err = NewPluginRegistryServer(ctx, cfg, h, kadDHT); err != nil {
+			return err
+		}
+		checker = health.NewChecker(healthServer, []string{pb.PluginRegistry_ServiceDesc.ServiceName}, server.healthChecks(kadDHT)...)
+		return nil
+	}, func(context.Context) error {
+		return server.Close()
+	})
+	// Registered ahead of the queue so it runs once the queue has stopped.
+	m.Add("pending pin jobs", nil, func(ctx context.Context) error {
+		server.pinQueue.Flush(ctx)
+		return ctx.Err()
+	})
+	m.Go("pin queue", func(ctx context.Context) error {
+		server.pinQueue.Run(ctx)
+		return nil
+	})
+	m.Go("pin reconciler", func(ctx context.Context) error {
+		server.pinQueue.RunReconciler(ctx, reconcileInterval)
+		return nil
+	})
+	m.Go("federation", func(ctx context.Context) error {
+		server.federation.Run(ctx, federationInterval)
+		return nil
+	})
+	m.Go("announcements", func(ctx context.Context) error {
+		server.announcer.Run(ctx, server.federation.Ingest)
+		return nil
+	})
+	m.Go("index publisher", func(ctx context.Context) error {
+		if name, err := server.index.Name(); err == nil {
+			log.Printf("Plugin index is published at %s", name.AsPath())
+		}
+		server.index.Run(ctx)
+		return nil
+	})
+	m.Go("reprovider", func(ctx context.Context) error {
+		server.runReprovider(ctx, reprovideInterval)
+		return nil
+	})
+
+	m.Go("health checks", func(ctx context.Context) error {
+		checker.Run(ctx, healthCheckInterval)
+		return nil
+	})
+	if cfg.Admin.Addr != "" {
+		admin := &http.Server{Addr: cfg.Admin.Addr}
+		m.Add("admin server", func(context.Context) error {
+			mux := http.NewServeMux()
+			mux.Handle("/healthz", checker)
+			mux.Handle("/metrics", metrics.Handler())
+			admin.Handler = mux
+
+			lis, err := net.Listen("tcp", cfg.Admin.Addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen: %w", err)
+			}
+			log.Printf("Serving admin endpoints on %s", lis.Addr())
+			go func() {
+				if err := admin.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					m.Fail(fmt.Errorf("failed to serve admin endpoints: %w", err))
+				}
+			}()
+			return nil
+		}, func(ctx context.Context) error {
+			return admin.Shutdown(ctx)
+		})
 	}
 
-	// let's use kbucket DHT instead
-	rt, err := kbucket.NewRoutingTable(
-		20, kbucket.ConvertPeerID(h.ID()), 10*time.Second, h.Peerstore(), time.Second, nil,
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(metrics.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(metrics.StreamServerInterceptor()),
 	)
+	m.Add("gRPC server", func(context.Context) error {
+		pb.RegisterPluginRegistryServer(grpcServer, server)
+		healthpb.RegisterHealthServer(grpcServer, healthServer)
+		reflection.Register(grpcServer)
 
-	// rt, err := kbucket.NewRoutingTable(
-	// 	20, kbucket.ConvertPeerID(h.ID()), h.Peerstore(), 10*time.Second,
-	// )
-	// Set up the DHT
-	// rt := kbucket.NewRoutingTable(20, kbucket.ConvertPeerID(h.ID()), h.Peerstore(), 10*time.Second)
+		lis, err := net.Listen("tcp", cfg.GRPC.Addr)
+		if err != nil {
+			return fmt.Errorf("failed to listen: %w", err)
+		}
+		log.Printf("Starting Spacecore Registry server on %s", lis.Addr())
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				m.Fail(fmt.Errorf("failed to serve gRPC server: %w", err))
+			}
+		}()
+		return nil
+	}, func(ctx context.Context) error {
+		// Tell health checkers to stop routing traffic here while draining.
+		healthServer.Shutdown()
+		return gracefulStop(ctx, grpcServer)
+	})
 
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create routing table: %w", err)
-	}
-	// dht, err := dht.New(ctx, h, dht.RoutingTable(rt))
+	return m.Run(ctx, cfg.ShutdownTimeout)
+}
 
-	// Set up the DHT
-	dht, err := dht.New(ctx, h)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create DHT: %w", err)
-	}
-	if err := dht.Bootstrap(ctx); err != nil {
-		return nil, nil, fmt.Errorf("failed to bootstrap DHT: %w", err)
+// gracefulStop waits for in-flight RPCs to finish, cutting them off when ctx
+// expires.
+func gracefulStop(ctx context.Context, s *grpc.Server) error {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.Stop()
+		<-done
+		return fmt.Errorf("in-flight RPCs cancelled: %w", ctx.Err())
 	}
-
-	// return h, rt, nil
-	return h, rt, nil
 }
 
-func Start(h host.Host, dht *kbucket.RoutingTable) {
-	ipfsClient, err := rpc.NewLocalApi()
-	if err != nil {
-		log.Fatalf("Failed to create IPFS client h: %v", err)
-	}
-	grpcServer := grpc.NewServer()
-	redisClient := redis.NewClient(&redis.Options{
-		// Addr: "localhost:6379", make this dynamic from env
-		Addr: fmt.Sprintf("%s:%s", "0.0.0.0", "6379"),
-	})
-	pb.RegisterPluginRegistryServer(grpcServer, &pluginRegistryServer{
-		ipfsClient:  ipfsClient,
-		redisClient: redisClient,
-	})
-	reflection.Register(grpcServer)
+// healthCheckInterval is how often dependencies are checked for the health
+// service.
+const healthCheckInterval = 15 * time.Second
 
-	lis, err := net.Listen("tcp", ":50051")
-	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
-	}
+// reconcileInterval is how often pinning providers are checked for content
+// they have lost.
+const reconcileInterval = 10 * time.Minute
 
-	log.Println("Starting Spacecore Registry server on port 50051")
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve gRPC server: %v", err)
+// pinningOptions converts the pinning configuration for pinning.Open.
+func pinningOptions(cfg config.PinningConfig, local content.Backend) pinning.Options {
+	opts := pinning.Options{
+		Providers:       cfg.Providers,
+		PinataJWT:       cfg.Pinata.JWT,
+		PinataHostNodes: cfg.Pinata.HostNodes,
+		Local:           local,
+	}
+	for _, service := range cfg.Services {
+		opts.Services = append(opts.Services, pinning.ServiceOptions{
+			Name:     service.Name,
+			Endpoint: service.Endpoint,
+			Token:    service.Token,
+			Origins:  service.Origins,
+		})
 	}
+	return opts
 }
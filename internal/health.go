@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"spacecore_registry/internal/health"
+
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+)
+
+// healthChecks lists the dependencies reported by the health service. The
+// registry can serve without DHT peers or remote pinning, since pins are
+// queued and retried, so only the store and IPFS node are required.
+func (s *pluginRegistryServer) healthChecks(kadDHT *dht.IpfsDHT) []health.Check {
+	checks := []health.Check{
+		{
+			Name:     "store",
+			Required: true,
+			Run: func(ctx context.Context) (string, error) {
+				return "", s.store.Ping(ctx)
+			},
+		},
+		{
+			Name:     "ipfs",
+			Required: true,
+			Run: func(ctx context.Context) (string, error) {
+				return "", s.content.Ping(ctx)
+			},
+		},
+		{
+			Name: "dht",
+			Run: func(ctx context.Context) (string, error) {
+				size := kadDHT.RoutingTable().Size()
+				detail := fmt.Sprintf("%d peers in routing table", size)
+				if size == 0 {
+					return detail, errors.New("routing table is empty")
+				}
+				return detail, nil
+			},
+		},
+	}
+	for _, pinner := range s.pinQueue.Pinners() {
+		checks = append(checks, health.Check{
+			Name: "pinning/" + pinner.Name(),
+			Run: func(ctx context.Context) (string, error) {
+				return "", pinner.Ping(ctx)
+			},
+		})
+	}
+	return checks
+}
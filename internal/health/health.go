@@ -0,0 +1,176 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const checkTimeout = 5 * time.Second
+
+// Check is one dependency of the registry.
+type Check struct {
+	// Name is also the service name its status is reported under in the
+	// gRPC health service.
+	Name string
+	// Required checks must pass for the registry to report SERVING. The
+	// others only affect their own status.
+	Required bool
+	// Run returns a short description of what it found, and an error if
+	// the dependency is unusable.
+	Run func(ctx context.Context) (string, error)
+}
+
+// Result is the outcome of the last run of a check.
+type Result struct {
+	Name      string    `json:"name"`
+	Healthy   bool      `json:"healthy"`
+	Required  bool      `json:"required"`
+	Detail    string    `json:"detail,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Latency   string    `json:"latency"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// Checker runs checks periodically and publishes their outcome to a gRPC
+// health server. The overall status, for the empty service name and for
+// every name in services, is SERVING only while all required checks pass.
+type Checker struct {
+	server   *health.Server
+	checks   []Check
+	services []string
+
+	mu      sync.RWMutex
+	results []Result
+}
+
+// NewChecker reports NOT_SERVING until the checks have run once.
+func NewChecker(server *health.Server, services []string, checks ...Check) *Checker {
+	c := &Checker{server: server, checks: checks, services: services}
+	c.setStatus("", false)
+	for _, check := range checks {
+		c.setStatus(check.Name, false)
+	}
+	return c
+}
+
+// Run checks every interval until ctx is cancelled.
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		c.CheckAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll runs every check concurrently and updates the reported statuses.
+func (c *Checker) CheckAll(ctx context.Context) {
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	serving := true
+	for _, result := range results {
+		c.setStatus(result.Name, result.Healthy)
+		if result.Required && !result.Healthy {
+			serving = false
+		}
+	}
+	c.setStatus("", serving)
+
+	c.mu.Lock()
+	c.results = results
+	c.mu.Unlock()
+}
+
+func run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	start := time.Now()
+	detail, err := check.Run(ctx)
+	result := Result{
+		Name:      check.Name,
+		Healthy:   err == nil,
+		Required:  check.Required,
+		Detail:    detail,
+		Latency:   time.Since(start).Round(time.Microsecond).String(),
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (c *Checker) setStatus(name string, healthy bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if healthy {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	c.server.SetServingStatus(name, status)
+	if name == "" {
+		for _, service := range c.services {
+			c.server.SetServingStatus(service, status)
+		}
+	}
+}
+
+// Serving reports whether every required check passed on the last run.
+func (c *Checker) Serving() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.results == nil {
+		return false
+	}
+	for _, result := range c.results {
+		if result.Required && !result.Healthy {
+			return false
+		}
+	}
+	return true
+}
+
+// Results returns the outcome of the last run of every check.
+func (c *Checker) Results() []Result {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]Result(nil), c.results...)
+}
+
+type report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+// ServeHTTP reports the result of every check as JSON, with status 503
+// while the registry is not serving.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp := report{Status: healthpb.HealthCheckResponse_SERVING.String(), Checks: c.Results()}
+	code := http.StatusOK
+	if !c.Serving() {
+		resp.Status = healthpb.HealthCheckResponse_NOT_SERVING.String()
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(resp)
+}
@@ -0,0 +1,358 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"spacecore_registry/internal/content"
+	"spacecore_registry/internal/store"
+	"spacecore_registry/pb"
+
+	"github.com/ipfs/boxo/ipns"
+	"github.com/ipfs/boxo/path"
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/routing"
+	mh "github.com/multiformats/go-multihash"
+)
+
+// IndexType identifies the layout of the root node.
+const IndexType = "spacecore-registry/index/v1"
+
+const (
+	// debounce batches changes that arrive close together into one publish.
+	debounce = 5 * time.Second
+	// republishInterval refreshes the IPNS record well before it expires.
+	republishInterval = 4 * time.Hour
+	recordLifetime    = 48 * time.Hour
+	recordTTL         = 5 * time.Minute
+	// flushTimeout bounds the last publish when Run is stopped.
+	flushTimeout = 30 * time.Second
+)
+
+// Publisher keeps the registry's plugin index as a dag-cbor DAG and
+// publishes its root under the IPNS name of the registry's libp2p key.
+//
+// The root maps each plugin name to a node listing its versions with their
+// CID, publisher and signature. After a change only the affected name nodes
+// and the root are rebuilt; unchanged names keep their CIDs.
+type Publisher struct {
+	store   store.MetadataStore
+	content content.Backend
+	values  routing.ValueStore
+	key     crypto.PrivKey
+
+	mu    sync.Mutex
+	names map[string]cid.Cid
+	dirty map[string]bool
+	root  cid.Cid
+	wake  chan struct{}
+}
+
+func NewPublisher(metadataStore store.MetadataStore, backend content.Backend, values routing.ValueStore, key crypto.PrivKey) *Publisher {
+	return &Publisher{
+		store:   metadataStore,
+		content: backend,
+		values:  values,
+		key:     key,
+		names:   make(map[string]cid.Cid),
+		dirty:   make(map[string]bool),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Name returns the IPNS name the index is published under.
+func (p *Publisher) Name() (ipns.Name, error) {
+	id, err := peer.IDFromPrivateKey(p.key)
+	if err != nil {
+		return ipns.Name{}, err
+	}
+	return ipns.NameFromPeer(id), nil
+}
+
+// Changed marks a plugin name for republishing.
+func (p *Publisher) Changed(name string) {
+	p.mu.Lock()
+	p.dirty[name] = true
+	p.mu.Unlock()
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run builds the full index, publishes it, and then republishes after
+// changes and every republishInterval until ctx is cancelled.
+func (p *Publisher) Run(ctx context.Context) {
+	if err := p.build(ctx); err != nil {
+		log.Printf("failed to build plugin index: %v", err)
+	}
+	p.publish(ctx)
+
+	ticker := time.NewTicker(republishInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			p.flush(ctx)
+			return
+		case <-ticker.C:
+		case <-p.wake:
+			select {
+			case <-ctx.Done():
+				p.flush(ctx)
+				return
+			case <-time.After(debounce):
+			}
+		}
+		p.publish(ctx)
+	}
+}
+
+// flush publishes changes still waiting for the debounce when Run is
+// stopped, so they aren't lost on shutdown.
+func (p *Publisher) flush(ctx context.Context) {
+	p.mu.Lock()
+	pending := len(p.dirty) > 0
+	p.mu.Unlock()
+	if !pending {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+	defer cancel()
+	p.publish(ctx)
+}
+
+// build marks every registered name dirty, so the next publish covers the
+// whole store.
+func (p *Publisher) build(ctx context.Context) error {
+	plugins, err := p.store.ListPlugins(ctx)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, plugin := range plugins {
+		p.dirty[plugin.Name] = true
+	}
+	return nil
+}
+
+func (p *Publisher) publish(ctx context.Context) {
+	root, err := p.update(ctx)
+	if err != nil {
+		log.Printf("failed to update plugin index: %v", err)
+		return
+	}
+	if !root.Defined() {
+		return
+	}
+	if err := p.putRecord(ctx, root); err != nil {
+		log.Printf("failed to publish plugin index %s: %v", root, err)
+		return
+	}
+	if err := p.content.Provide(ctx, path.FromCid(root)); err != nil {
+		log.Printf("failed to provide plugin index %s: %v", root, err)
+	}
+}
+
+// update rebuilds the dirty name nodes and the root, and pins the new root in
+// place of the old one. On failure every dirty name is kept for the next
+// publish, so the root isn't left behind the store.
+func (p *Publisher) update(ctx context.Context) (_ cid.Cid, err error) {
+	p.mu.Lock()
+	dirty := p.dirty
+	p.dirty = make(map[string]bool)
+	p.mu.Unlock()
+	defer func() {
+		if err != nil {
+			p.mu.Lock()
+			for name := range dirty {
+				p.dirty[name] = true
+			}
+			p.mu.Unlock()
+		}
+	}()
+
+	if len(dirty) == 0 && p.root.Defined() {
+		return p.root, nil
+	}
+	for name := range dirty {
+		c, err := p.putName(ctx, name)
+		if err != nil {
+			return cid.Undef, fmt.Errorf("index node for %s: %w", name, err)
+		}
+		if c.Defined() {
+			p.names[name] = c
+		} else {
+			delete(p.names, name)
+		}
+	}
+
+	root, err := p.putRoot(ctx)
+	if err != nil {
+		return cid.Undef, err
+	}
+	if root == p.root {
+		return root, nil
+	}
+	if err := p.content.Pin(ctx, path.FromCid(root)); err != nil {
+		return cid.Undef, fmt.Errorf("failed to pin index root: %w", err)
+	}
+	if p.root.Defined() {
+		if err := p.content.Unpin(ctx, path.FromCid(p.root)); err != nil {
+			log.Printf("failed to unpin previous index root %s: %v", p.root, err)
+		}
+	}
+	p.root = root
+	return root, nil
+}
+
+// putName stores the node listing every version of name. It returns
+// cid.Undef if the name has no versions left.
+func (p *Publisher) putName(ctx context.Context, name string) (cid.Cid, error) {
+	versions, err := p.store.ListVersions(ctx, name)
+	if err != nil {
+		return cid.Undef, err
+	}
+	entries := make(map[string]interface{}, len(versions))
+	for _, version := range versions {
+		plugin, err := p.store.GetPlugin(ctx, name, version)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return cid.Undef, err
+		}
+		entry, err := versionEntry(plugin)
+		if err != nil {
+			return cid.Undef, err
+		}
+		entries[version] = entry
+	}
+	if len(entries) == 0 {
+		return cid.Undef, nil
+	}
+	return p.put(ctx, map[string]interface{}{
+		"name":     name,
+		"versions": entries,
+	})
+}
+
+func versionEntry(plugin *pb.Plugin) (map[string]interface{}, error) {
+	pluginPath, err := path.NewPath(plugin.Cid)
+	if err != nil {
+		return nil, err
+	}
+	immutable, err := path.NewImmutablePath(pluginPath)
+	if err != nil {
+		return nil, err
+	}
+	entry := map[string]interface{}{
+		"cid":        immutable.RootCid(),
+		"publisher":  plugin.Publisher,
+		"signature":  plugin.Signature,
+		"publicKey":  plugin.PublicKey,
+		"yanked":     plugin.Yanked,
+		"yankReason": plugin.YankReason,
+	}
+	if len(plugin.Artifacts) == 0 {
+		// The version's platforms are those its signature covers.
+		entry["platforms"] = platformsNode(plugin.Platforms)
+		return entry, nil
+	}
+	artifacts := make([]interface{}, 0, len(plugin.Artifacts))
+	for _, artifact := range plugin.Artifacts {
+		p, err := path.NewPath(artifact.Cid)
+		if err != nil {
+			return nil, err
+		}
+		immutable, err := path.NewImmutablePath(p)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, map[string]interface{}{
+			"cid":       immutable.RootCid(),
+			"platforms": platformsNode(artifact.Platforms),
+			"publisher": artifact.Publisher,
+			"signature": artifact.Signature,
+			"publicKey": artifact.PublicKey,
+			"sha256":    artifact.Sha256,
+			"size":      artifact.Size,
+		})
+	}
+	entry["artifacts"] = artifacts
+	return entry, nil
+}
+
+func platformsNode(platforms []*pb.Platform) []interface{} {
+	node := make([]interface{}, 0, len(platforms))
+	for _, platform := range platforms {
+		node = append(node, map[string]interface{}{"os": platform.Os, "arch": platform.Arch})
+	}
+	return node
+}
+
+func (p *Publisher) putRoot(ctx context.Context) (cid.Cid, error) {
+	id, err := peer.IDFromPrivateKey(p.key)
+	if err != nil {
+		return cid.Undef, err
+	}
+	publicKey, err := crypto.MarshalPublicKey(p.key.GetPublic())
+	if err != nil {
+		return cid.Undef, err
+	}
+	// Map keys are sorted when encoded, so the same index always has the
+	// same CID.
+	plugins := make(map[string]interface{}, len(p.names))
+	for name, c := range p.names {
+		plugins[name] = c
+	}
+	return p.put(ctx, map[string]interface{}{
+		"type":      IndexType,
+		"registry":  id.String(),
+		"publicKey": publicKey,
+		"plugins":   plugins,
+	})
+}
+
+func (p *Publisher) put(ctx context.Context, obj map[string]interface{}) (cid.Cid, error) {
+	nd, err := cbornode.WrapObject(obj, mh.SHA2_256, -1)
+	if err != nil {
+		return cid.Undef, err
+	}
+	if err := p.content.AddNode(ctx, nd); err != nil {
+		return cid.Undef, err
+	}
+	return nd.Cid(), nil
+}
+
+// putRecord signs an IPNS record pointing at root and stores it on the DHT.
+// The sequence number is the publish time, so it keeps increasing across
+// restarts without persisting it.
+func (p *Publisher) putRecord(ctx context.Context, root cid.Cid) error {
+	name, err := p.Name()
+	if err != nil {
+		return err
+	}
+	seq := uint64(time.Now().UnixNano())
+	rec, err := ipns.NewRecord(p.key, path.FromCid(root), seq, time.Now().Add(recordLifetime), recordTTL)
+	if err != nil {
+		return err
+	}
+	data, err := ipns.MarshalRecord(rec)
+	if err != nil {
+		return err
+	}
+	if err := p.values.PutValue(ctx, string(name.RoutingKey()), data); err != nil {
+		return err
+	}
+	log.Printf("Published plugin index %s at %s", root, name.AsPath())
+	return nil
+}
@@ -0,0 +1,131 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Manager starts components in the order they were added and stops the
+// ones that started in reverse order.
+type Manager struct {
+	components []component
+	failed     chan error
+}
+
+type component struct {
+	name  string
+	start func(ctx context.Context) error
+	stop  func(ctx context.Context) error
+}
+
+// StartError is returned by Run when a component failed to start.
+type StartError struct {
+	Component string
+	Err       error
+}
+
+func (e *StartError) Error() string {
+	return fmt.Sprintf("failed to start %s: %v", e.Component, e.Err)
+}
+
+func (e *StartError) Unwrap() error {
+	return e.Err
+}
+
+func New() *Manager {
+	return &Manager{failed: make(chan error, 1)}
+}
+
+// Add registers a component. Either function may be nil. start receives a
+// context that stays valid until every component has stopped, so it may be
+// used for the lifetime of what it creates; stop receives one that expires
+// at the shutdown deadline.
+func (m *Manager) Add(name string, start, stop func(ctx context.Context) error) {
+	m.components = append(m.components, component{name: name, start: start, stop: stop})
+}
+
+// Go registers a component that runs in the background until it is stopped.
+// run must return once its context is cancelled; an error returned before
+// then shuts the manager down.
+func (m *Manager) Go(name string, run func(ctx context.Context) error) {
+	var cancel context.CancelFunc
+	done := make(chan struct{})
+	m.Add(name, func(ctx context.Context) error {
+		ctx, cancel = context.WithCancel(ctx)
+		go func() {
+			defer close(done)
+			if err := run(ctx); err != nil && ctx.Err() == nil {
+				m.Fail(fmt.Errorf("%s: %w", name, err))
+			}
+		}()
+		return nil
+	}, func(ctx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// Fail shuts the manager down because a running component failed. Only the
+// first failure is reported.
+func (m *Manager) Fail(err error) {
+	select {
+	case m.failed <- err:
+	default:
+	}
+}
+
+// Run starts every component, waits until ctx is done or a component fails,
+// then stops the started components in reverse order within timeout. It
+// returns a *StartError if a component failed to start, joined with any
+// failure while running or stopping.
+func (m *Manager) Run(ctx context.Context, timeout time.Duration) error {
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	var err error
+	var started []component
+	for _, c := range m.components {
+		if ctx.Err() != nil {
+			break
+		}
+		if c.start != nil {
+			log.Printf("Starting %s", c.name)
+			if startErr := c.start(runCtx); startErr != nil {
+				err = &StartError{Component: c.name, Err: startErr}
+				break
+			}
+		}
+		started = append(started, c)
+	}
+	if err == nil {
+		select {
+		case <-ctx.Done():
+			log.Printf("Shutting down")
+		case err = <-m.failed:
+			log.Printf("Shutting down: %v", err)
+		}
+	}
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), timeout)
+	defer stopCancel()
+	errs := []error{err}
+	for i := len(started) - 1; i >= 0; i-- {
+		c := started[i]
+		if c.stop == nil {
+			continue
+		}
+		log.Printf("Stopping %s", c.name)
+		if stopErr := c.stop(stopCtx); stopErr != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", c.name, stopErr))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,235 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"spacecore_registry/pb"
+
+	"github.com/Masterminds/semver/v3"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	maxDescriptionLength  = 1024
+	maxManifestListLength = 32
+	maxConfigSchemaSize   = 64 << 10
+)
+
+var (
+	keywordPattern    = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+	capabilityPattern = regexp.MustCompile(`^[a-z][a-z0-9.-]*$`)
+	permissionPattern = regexp.MustCompile(`^[a-z][a-z0-9.-]*(:[A-Za-z0-9._/*-]+)?$`)
+	// licensePattern accepts SPDX expressions: identifiers, optionally with
+	// "+", joined by AND, OR and WITH and grouped with parentheses. The
+	// identifiers themselves aren't checked against the SPDX list.
+	licensePattern = regexp.MustCompile(`^[()]*(LicenseRef-)?[A-Za-z0-9][A-Za-z0-9.-]*\+?[()]*( (AND|OR|WITH) [()]*(LicenseRef-)?[A-Za-z0-9][A-Za-z0-9.-]*\+?[()]*)*$`)
+)
+
+// normalizeManifest trims and lowercases manifest fields in place and checks
+// them, returning InvalidArgument on the first problem. A nil manifest is
+// valid.
+func normalizeManifest(m *pb.PluginManifest) error {
+	if m == nil {
+		return nil
+	}
+	m.Description = strings.TrimSpace(m.Description)
+	m.License = strings.TrimSpace(m.License)
+	m.Homepage = strings.TrimSpace(m.Homepage)
+	m.Entrypoint = strings.TrimSpace(m.Entrypoint)
+	m.ApiVersion = strings.TrimSpace(m.ApiVersion)
+	m.ConfigSchema = strings.TrimSpace(m.ConfigSchema)
+	m.Keywords = normalizeList(m.Keywords)
+	m.Capabilities = normalizeList(m.Capabilities)
+	m.Permissions = normalizeList(m.Permissions)
+
+	if len(m.Description) > maxDescriptionLength {
+		return manifestError("description is longer than %d bytes", maxDescriptionLength)
+	}
+	if err := checkList("keyword", m.Keywords, keywordPattern); err != nil {
+		return err
+	}
+	if err := checkList("capability", m.Capabilities, capabilityPattern); err != nil {
+		return err
+	}
+	if err := checkList("permission", m.Permissions, permissionPattern); err != nil {
+		return err
+	}
+	if m.License != "" && (!licensePattern.MatchString(m.License) || strings.Count(m.License, "(") != strings.Count(m.License, ")")) {
+		return manifestError("license %q is not an SPDX expression", m.License)
+	}
+	if m.Homepage != "" {
+		u, err := url.Parse(m.Homepage)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return manifestError("homepage %q is not an http or https URL", m.Homepage)
+		}
+	}
+	if m.Entrypoint != "" {
+		clean := path.Clean(m.Entrypoint)
+		if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") || strings.Contains(m.Entrypoint, "\\") {
+			return manifestError("entrypoint %q must be a relative path inside the plugin", m.Entrypoint)
+		}
+		m.Entrypoint = clean
+	}
+	if m.ApiVersion != "" {
+		if _, err := semver.NewConstraint(m.ApiVersion); err != nil {
+			return manifestError("api_version %q is not a semver range: %v", m.ApiVersion, err)
+		}
+	}
+	if len(m.Dependencies) > maxManifestListLength {
+		return manifestError("more than %d dependencies", maxManifestListLength)
+	}
+	seen := make(map[string]bool)
+	for _, dep := range m.Dependencies {
+		dep.Name = strings.TrimSpace(dep.Name)
+		dep.Version = strings.TrimSpace(dep.Version)
+		if !pluginNamePattern.MatchString(dep.Name) {
+			return manifestError("invalid dependency name %q", dep.Name)
+		}
+		if seen[dep.Name] {
+			return manifestError("dependency %s is listed more than once", dep.Name)
+		}
+		seen[dep.Name] = true
+		if _, err := matchVersions(nil, dep.Version); err != nil {
+			return manifestError("dependency %s has invalid version %q: %v", dep.Name, dep.Version, err)
+		}
+	}
+	if m.ConfigSchema != "" {
+		if len(m.ConfigSchema) > maxConfigSchemaSize {
+			return manifestError("config_schema is larger than %d bytes", maxConfigSchemaSize)
+		}
+		var schema map[string]any
+		if err := json.Unmarshal([]byte(m.ConfigSchema), &schema); err != nil {
+			return manifestError("config_schema is not a JSON object: %v", err)
+		}
+		if t, ok := schema["type"]; ok && t != "object" {
+			return manifestError("config_schema must describe an object, not %v", t)
+		}
+	}
+	return nil
+}
+
+// normalizeList trims, lowercases and deduplicates entries, dropping empty
+// ones. Permission scopes keep their case.
+func normalizeList(values []string) []string {
+	var out []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if kind, scope, ok := strings.Cut(v, ":"); ok {
+			v = strings.ToLower(kind) + ":" + scope
+		} else {
+			v = strings.ToLower(v)
+		}
+		if v != "" && !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func checkList(kind string, values []string, pattern *regexp.Regexp) error {
+	if len(values) > maxManifestListLength {
+		return manifestError("more than %d %s entries", maxManifestListLength, kind)
+	}
+	for _, v := range values {
+		if !pattern.MatchString(v) {
+			return manifestError("invalid %s %q", kind, v)
+		}
+	}
+	return nil
+}
+
+// normalizedManifest returns a normalized copy of the manifest a publisher
+// sent, leaving sent as it is so its digest can still be checked.
+func normalizedManifest(sent *pb.PluginManifest) (*pb.PluginManifest, error) {
+	if sent == nil {
+		return nil, nil
+	}
+	m := proto.Clone(sent).(*pb.PluginManifest)
+	if err := normalizeManifest(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// manifestDigest returns the hex SHA-256 of the canonical encoding of a
+// manifest as the publisher sent it, which the publisher signature covers,
+// or "" when there is no manifest.
+//
+// The canonical encoding is DAG-CBOR of a map holding the manifest's
+// non-empty fields under their protobuf field names. Lists keep their order
+// and each dependency is a map of its non-empty name and version. DAG-CBOR
+// sorts map keys, shorter first and then bytewise, and has exactly one
+// encoding for every value, so any implementation produces the same bytes.
+func manifestDigest(m *pb.PluginManifest) (string, error) {
+	if m == nil || proto.Size(m) == 0 {
+		return "", nil
+	}
+	encoded, err := cbornode.DumpObject(canonicalManifest(m))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalManifest returns the map manifestDigest encodes.
+func canonicalManifest(m *pb.PluginManifest) map[string]interface{} {
+	obj := make(map[string]interface{})
+	setString := func(key, v string) {
+		if v != "" {
+			obj[key] = v
+		}
+	}
+	setList := func(key string, v []string) {
+		if len(v) > 0 {
+			obj[key] = v
+		}
+	}
+	setString("description", m.Description)
+	setList("keywords", m.Keywords)
+	setString("license", m.License)
+	setString("homepage", m.Homepage)
+	setString("entrypoint", m.Entrypoint)
+	setString("api_version", m.ApiVersion)
+	setList("capabilities", m.Capabilities)
+	setList("permissions", m.Permissions)
+	setString("config_schema", m.ConfigSchema)
+	if len(m.Dependencies) > 0 {
+		deps := make([]interface{}, 0, len(m.Dependencies))
+		for _, dep := range m.Dependencies {
+			entry := make(map[string]interface{})
+			if dep.Name != "" {
+				entry["name"] = dep.Name
+			}
+			if dep.Version != "" {
+				entry["version"] = dep.Version
+			}
+			deps = append(deps, entry)
+		}
+		obj["dependencies"] = deps
+	}
+	return obj
+}
+
+// signedManifest returns the manifest plugin's publisher signed: the one
+// kept in signed_manifest when normalizing changed it, or else manifest.
+func signedManifest(plugin *pb.Plugin) *pb.PluginManifest {
+	if plugin.SignedManifest != nil {
+		return plugin.SignedManifest
+	}
+	return plugin.Manifest
+}
+
+func manifestError(format string, args ...any) error {
+	return status.Errorf(codes.InvalidArgument, "invalid manifest: "+format, args...)
+}
@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"spacecore_registry/pb"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNormalizeManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest *pb.PluginManifest
+		wantErr  string
+	}{
+		{"nil", nil, ""},
+		{"empty", &pb.PluginManifest{}, ""},
+		{"valid", &pb.PluginManifest{
+			License:      "MIT OR (Apache-2.0 WITH LLVM-exception)",
+			Homepage:     "https://example.com/plugin",
+			Entrypoint:   "bin/plugin",
+			ApiVersion:   ">=1.2, <2",
+			Keywords:     []string{"storage", "ipfs"},
+			Permissions:  []string{"net:example.com", "fs.read:/data/*"},
+			Dependencies: []*pb.Dependency{{Name: "lib", Version: "^1"}},
+			ConfigSchema: `{"type": "object"}`,
+		}, ""},
+		{"long description", &pb.PluginManifest{Description: strings.Repeat("x", maxDescriptionLength+1)}, "description"},
+		{"bad keyword", &pb.PluginManifest{Keywords: []string{"two words"}}, "keyword"},
+		{"too many keywords", &pb.PluginManifest{Keywords: numbered("k", maxManifestListLength+1)}, "keyword"},
+		{"bad capability", &pb.PluginManifest{Capabilities: []string{"1abc"}}, "capability"},
+		{"bad permission", &pb.PluginManifest{Permissions: []string{"net:has space"}}, "permission"},
+		{"bad license", &pb.PluginManifest{License: "MIT or GPL"}, "license"},
+		{"unbalanced license", &pb.PluginManifest{License: "(MIT OR GPL-2.0"}, "license"},
+		{"non-http homepage", &pb.PluginManifest{Homepage: "ftp://example.com"}, "homepage"},
+		{"homepage without host", &pb.PluginManifest{Homepage: "https://"}, "homepage"},
+		{"absolute entrypoint", &pb.PluginManifest{Entrypoint: "/bin/sh"}, "entrypoint"},
+		{"escaping entrypoint", &pb.PluginManifest{Entrypoint: "bin/../../etc"}, "entrypoint"},
+		{"windows entrypoint", &pb.PluginManifest{Entrypoint: `bin\plugin`}, "entrypoint"},
+		{"bad api version", &pb.PluginManifest{ApiVersion: "one"}, "api_version"},
+		{"bad dependency name", &pb.PluginManifest{Dependencies: []*pb.Dependency{{Name: "Bad Name"}}}, "dependency name"},
+		{"duplicate dependency", &pb.PluginManifest{Dependencies: []*pb.Dependency{{Name: "lib"}, {Name: " lib "}}}, "more than once"},
+		{"bad dependency version", &pb.PluginManifest{Dependencies: []*pb.Dependency{{Name: "lib", Version: "!!"}}}, "invalid version"},
+		{"schema not json", &pb.PluginManifest{ConfigSchema: "{"}, "config_schema"},
+		{"schema not an object", &pb.PluginManifest{ConfigSchema: `{"type": "array"}`}, "config_schema"},
+		{"schema too large", &pb.PluginManifest{ConfigSchema: `{"x": "` + strings.Repeat("x", maxConfigSchemaSize) + `"}`}, "config_schema"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := normalizeManifest(tt.manifest)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want InvalidArgument mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeManifestCleansFields(t *testing.T) {
+	m := &pb.PluginManifest{
+		Description:  "  A plugin  ",
+		Entrypoint:   "./bin//plugin",
+		Keywords:     []string{" Storage", "storage", "", "IPFS"},
+		Permissions:  []string{"NET:Example.com", "net:Example.com"},
+		Dependencies: []*pb.Dependency{{Name: " lib ", Version: " ^1 "}},
+	}
+	if err := normalizeManifest(m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Description != "A plugin" || m.Entrypoint != "bin/plugin" {
+		t.Errorf("description %q, entrypoint %q", m.Description, m.Entrypoint)
+	}
+	if want := []string{"storage", "ipfs"}; !slices.Equal(m.Keywords, want) {
+		t.Errorf("keywords = %v, want %v", m.Keywords, want)
+	}
+	if want := []string{"net:Example.com"}; !slices.Equal(m.Permissions, want) {
+		t.Errorf("permissions = %v, want %v", m.Permissions, want)
+	}
+	if dep := m.Dependencies[0]; dep.Name != "lib" || dep.Version != "^1" {
+		t.Errorf("dependency = %s %q", dep.Name, dep.Version)
+	}
+}
+
+func TestManifestDigest(t *testing.T) {
+	for _, m := range []*pb.PluginManifest{nil, {}} {
+		if sum, err := manifestDigest(m); err != nil || sum != "" {
+			t.Errorf("manifestDigest(%v) = %q, %v, want empty", m, sum, err)
+		}
+	}
+	a, _ := manifestDigest(&pb.PluginManifest{Permissions: []string{"net"}})
+	b, _ := manifestDigest(&pb.PluginManifest{Permissions: []string{"net"}})
+	c, _ := manifestDigest(&pb.PluginManifest{Permissions: []string{"net", "fs.write"}})
+	if a == "" || a != b || a == c {
+		t.Errorf("digests %q, %q, %q: want equal manifests to match and different ones to differ", a, b, c)
+	}
+}
+
+func TestManifestCanonicalEncoding(t *testing.T) {
+	m := &pb.PluginManifest{Keywords: []string{"b", "a"}, License: "MIT"}
+	encoded, err := cbornode.DumpObject(canonicalManifest(m))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A map of two entries, "license" sorting first as the shorter key, and
+	// the keywords in the order given.
+	want, _ := hex.DecodeString("a2" + "676c6963656e7365" + "634d4954" + "686b6579776f726473" + "82" + "6162" + "6161")
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("encoding = %x, want %x", encoded, want)
+	}
+	sum := sha256.Sum256(want)
+	if digest, _ := manifestDigest(m); digest != hex.EncodeToString(sum[:]) {
+		t.Errorf("digest = %s, want SHA-256 of the encoding", digest)
+	}
+}
+
+func TestNormalizedManifestKeepsSent(t *testing.T) {
+	sent := &pb.PluginManifest{Keywords: []string{" Storage ", "storage"}}
+	normalized, err := normalizedManifest(sent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(normalized.Keywords, []string{"storage"}) {
+		t.Errorf("normalized keywords = %q", normalized.Keywords)
+	}
+	if !slices.Equal(sent.Keywords, []string{" Storage ", "storage"}) {
+		t.Errorf("sent manifest was changed to %q", sent.Keywords)
+	}
+	sentSum, _ := manifestDigest(sent)
+	normalizedSum, _ := manifestDigest(normalized)
+	if sentSum == normalizedSum {
+		t.Error("normalizing did not change the digest")
+	}
+	if m, err := normalizedManifest(nil); m != nil || err != nil {
+		t.Errorf("normalizedManifest(nil) = %v, %v", m, err)
+	}
+}
+
+func numbered(prefix string, n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf("%s%d", prefix, i)
+	}
+	return out
+}
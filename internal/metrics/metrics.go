@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "spacecore"
+
+var (
+	RPCRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "gRPC requests handled, by method and status code.",
+	}, []string{"method", "code"})
+
+	RPCDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Time taken to handle gRPC requests, by method and status code.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+	}, []string{"method", "code"})
+
+	UploadedBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "uploaded_bytes_total",
+		Help:      "Plugin content received from publishers.",
+	})
+
+	DownloadedBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "downloaded_bytes_total",
+		Help:      "Plugin content sent to clients.",
+	})
+
+	IPFSDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "ipfs",
+		Name:      "operation_duration_seconds",
+		Help:      "Time taken by the IPFS node to add and pin content, by operation.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 4, 9),
+	}, []string{"operation"})
+
+	PinataRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "pinata",
+		Name:      "requests_total",
+		Help:      "Calls to the Pinata API, by operation and HTTP status code, or \"error\" when no response arrived.",
+	}, []string{"operation", "code"})
+
+	RedisErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "redis",
+		Name:      "errors_total",
+		Help:      "Failed Redis commands, by command.",
+	}, []string{"command"})
+)
+
+// Handler serves every registered metric in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveIPFS records an IPFS operation that began at start.
+func ObserveIPFS(operation string, start time.Time) {
+	IPFSDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+// RegisterNetwork exports the number of connected libp2p peers and the size
+// of the DHT routing table. It must only be called once per process.
+func RegisterNetwork(h host.Host, kadDHT *dht.IpfsDHT) {
+	prometheus.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "libp2p",
+			Name:      "peers",
+			Help:      "Peers the libp2p host is connected to.",
+		}, func() float64 { return float64(len(h.Network().Peers())) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "dht",
+			Name:      "routing_table_size",
+			Help:      "Peers in the Kademlia routing table.",
+		}, func() float64 { return float64(kadDHT.RoutingTable().Size()) }),
+	)
+}
+
+func observeRPC(method string, start time.Time, err error) {
+	code := status.Code(err).String()
+	RPCRequests.WithLabelValues(method, code).Inc()
+	RPCDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
+
+// UnaryServerInterceptor counts and times unary RPCs.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor counts and times streaming RPCs.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeRPC(info.FullMethod, start, err)
+		return err
+	}
+}
@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"spacecore_registry/internal/store"
+	"spacecore_registry/pb"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Actions covered by RequestSignature.
+const (
+	actionTransfer         = "transfer"
+	actionAddMaintainer    = "add-maintainer"
+	actionRemoveMaintainer = "remove-maintainer"
+	actionYank             = "yank"
+	actionSetReplication   = "set-replication"
+)
+
+// defaultReplicationTarget is the set-replication target that clears a
+// namespace's policy.
+const defaultReplicationTarget = "default"
+
+// pluginNamePattern allows an optional "<namespace>/" prefix. ":" is excluded
+// because it separates name and version in store keys.
+var pluginNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*(/[A-Za-z0-9][A-Za-z0-9._-]*)?$`)
+
+func validatePluginName(name string) error {
+	if !pluginNamePattern.MatchString(name) {
+		return status.Errorf(codes.InvalidArgument, "invalid plugin name %q, want [namespace/]name", name)
+	}
+	return nil
+}
+
+// namespaceOf returns the namespace that owns a plugin name.
+func namespaceOf(name string) string {
+	ns, _, _ := strings.Cut(name, "/")
+	return ns
+}
+
+func canPublish(ns *pb.Namespace, publisher peer.ID) bool {
+	id := publisher.String()
+	return ns.Owner == id || slices.Contains(ns.Maintainers, id)
+}
+
+// authorizePublisher checks that publisher may register plugins under name,
+// claiming the namespace for them if nobody owns it yet.
+func (s *pluginRegistryServer) authorizePublisher(ctx context.Context, name string, publisher peer.ID) error {
+	nsName := namespaceOf(name)
+	ns, err := s.store.GetNamespace(ctx, nsName)
+	if errors.Is(err, store.ErrNotFound) {
+		err = s.store.CreateNamespace(ctx, &pb.Namespace{Name: nsName, Owner: publisher.String()})
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, store.ErrExists) {
+			return err
+		}
+		// Lost a race to claim it; check against the winner.
+		ns, err = s.store.GetNamespace(ctx, nsName)
+	}
+	if err != nil {
+		return err
+	}
+	if !canPublish(ns, publisher) {
+		return status.Errorf(codes.PermissionDenied, "%s may not publish to namespace %s", publisher, nsName)
+	}
+	return nil
+}
+
+// checkPublisherKey turns away a publish before any content is added when
+// the key it claims to be signed with may not publish under name. The
+// signature covers the CID of the content, so it is only verified later,
+// by publishPlugin.
+func (s *pluginRegistryServer) checkPublisherKey(ctx context.Context, name string, publicKey, signature []byte) error {
+	_, publisher, err := signerKey(publicKey, signature)
+	if err != nil {
+		return err
+	}
+	ns, err := s.store.GetNamespace(ctx, namespaceOf(name))
+	if errors.Is(err, store.ErrNotFound) {
+		// Unclaimed; publishPlugin claims it once the signature checks out.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !canPublish(ns, publisher) {
+		return status.Errorf(codes.PermissionDenied, "%s may not publish to namespace %s", publisher, ns.Name)
+	}
+	return nil
+}
+
+// updateOwnedNamespace verifies an admin request and applies fn to the
+// namespace, provided the request was signed by its current owner.
+func (s *pluginRegistryServer) updateOwnedNamespace(ctx context.Context, auth *pb.RequestSignature, action, nsName, target string, fn func(*pb.Namespace) error) (*pb.NamespaceResponse, error) {
+	signer, err := verifyRequestSignature(auth, action, nsName, target)
+	if err != nil {
+		return nil, err
+	}
+	ns, err := s.store.UpdateNamespace(ctx, nsName, func(ns *pb.Namespace) error {
+		// Checked inside the update so a concurrent transfer can't slip past.
+		if ns.Owner != signer.String() {
+			return status.Errorf(codes.PermissionDenied, "%s does not own namespace %s", signer, nsName)
+		}
+		if err := useSequence(ns, auth); err != nil {
+			return err
+		}
+		return fn(ns)
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return &pb.NamespaceResponse{Namespace: ns}, nil
+}
+
+func parsePeerID(s string) (peer.ID, error) {
+	id, err := peer.Decode(s)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid peer ID %q: %v", s, err)
+	}
+	return id, nil
+}
+
+func (s *pluginRegistryServer) GetNamespace(ctx context.Context, req *pb.GetNamespaceRequest) (*pb.NamespaceResponse, error) {
+	ns, err := s.store.GetNamespace(ctx, req.Namespace)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return &pb.NamespaceResponse{Namespace: ns}, nil
+}
+
+func (s *pluginRegistryServer) TransferNamespace(ctx context.Context, req *pb.TransferNamespaceRequest) (*pb.NamespaceResponse, error) {
+	newOwner, err := parsePeerID(req.NewOwner)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateOwnedNamespace(ctx, req.Auth, actionTransfer, req.Namespace, req.NewOwner, func(ns *pb.Namespace) error {
+		ns.Owner = newOwner.String()
+		// The owner can always publish; don't list them as a maintainer too.
+		ns.Maintainers = slices.DeleteFunc(ns.Maintainers, func(m string) bool { return m == ns.Owner })
+		return nil
+	})
+}
+
+func (s *pluginRegistryServer) AddMaintainer(ctx context.Context, req *pb.MaintainerRequest) (*pb.NamespaceResponse, error) {
+	maintainer, err := parsePeerID(req.Maintainer)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateOwnedNamespace(ctx, req.Auth, actionAddMaintainer, req.Namespace, req.Maintainer, func(ns *pb.Namespace) error {
+		id := maintainer.String()
+		if id != ns.Owner && !slices.Contains(ns.Maintainers, id) {
+			ns.Maintainers = append(ns.Maintainers, id)
+		}
+		return nil
+	})
+}
+
+func (s *pluginRegistryServer) RemoveMaintainer(ctx context.Context, req *pb.MaintainerRequest) (*pb.NamespaceResponse, error) {
+	maintainer, err := parsePeerID(req.Maintainer)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateOwnedNamespace(ctx, req.Auth, actionRemoveMaintainer, req.Namespace, req.Maintainer, func(ns *pb.Namespace) error {
+		id := maintainer.String()
+		if !slices.Contains(ns.Maintainers, id) {
+			return status.Errorf(codes.NotFound, "%s is not a maintainer of %s", id, ns.Name)
+		}
+		ns.Maintainers = slices.DeleteFunc(ns.Maintainers, func(m string) bool { return m == id })
+		return nil
+	})
+}
+
+func (s *pluginRegistryServer) SetReplicationPolicy(ctx context.Context, req *pb.SetReplicationPolicyRequest) (*pb.NamespaceResponse, error) {
+	target := defaultReplicationTarget
+	if req.MinReplicas != nil {
+		if *req.MinReplicas < 0 || int(*req.MinReplicas) > s.pinQueue.Providers() {
+			return nil, status.Errorf(codes.InvalidArgument, "min_replicas must be between 0 and %d, the number of configured pinning providers", s.pinQueue.Providers())
+		}
+		target = strconv.Itoa(int(*req.MinReplicas))
+	}
+	return s.updateOwnedNamespace(ctx, req.Auth, actionSetReplication, req.Namespace, target, func(ns *pb.Namespace) error {
+		ns.MinReplicas = req.MinReplicas
+		return nil
+	})
+}
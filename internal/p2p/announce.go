@@ -0,0 +1,121 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"spacecore_registry/pb"
+
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/discovery/routing"
+	"google.golang.org/protobuf/proto"
+)
+
+// AnnouncementTopic carries PluginAnnouncement messages between registries.
+const AnnouncementTopic = "spacecore-registry/announcements/1"
+
+// IngestFunc handles a validated announcement from another registry.
+type IngestFunc func(from peer.ID, a *pb.PluginAnnouncement)
+
+// Announcer publishes plugin announcements over GossipSub and hands those of
+// other registries to an IngestFunc.
+type Announcer struct {
+	self  peer.ID
+	topic *pubsub.Topic
+	sub   *pubsub.Subscription
+}
+
+// NewAnnouncer joins the announcement topic. Messages are signed with the
+// host key, and incoming ones are dropped unless validate accepts the plugin
+// they carry.
+func NewAnnouncer(ctx context.Context, h host.Host, kadDHT *dht.IpfsDHT, validate ValidateFunc) (*Announcer, error) {
+	ps, err := pubsub.NewGossipSub(ctx, h, pubsub.WithDiscovery(routing.NewRoutingDiscovery(kadDHT)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start gossipsub: %w", err)
+	}
+	err = ps.RegisterTopicValidator(AnnouncementTopic, func(ctx context.Context, from peer.ID, msg *pubsub.Message) bool {
+		a, err := decodeAnnouncement(msg.Data)
+		if err == nil {
+			err = validate(a.Plugin)
+		}
+		if err != nil {
+			log.Printf("rejecting announcement from %s: %v", msg.GetFrom(), err)
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	topic, err := ps.Join(AnnouncementTopic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join %s: %w", AnnouncementTopic, err)
+	}
+	sub, err := topic.Subscribe()
+	if err != nil {
+		topic.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", AnnouncementTopic, err)
+	}
+	return &Announcer{self: h.ID(), topic: topic, sub: sub}, nil
+}
+
+func decodeAnnouncement(data []byte) (*pb.PluginAnnouncement, error) {
+	a := &pb.PluginAnnouncement{}
+	if err := proto.Unmarshal(data, a); err != nil {
+		return nil, err
+	}
+	if a.Plugin == nil {
+		return nil, errors.New("announcement has no plugin")
+	}
+	switch a.Kind {
+	case pb.PluginAnnouncement_KIND_REGISTERED, pb.PluginAnnouncement_KIND_YANKED:
+	default:
+		return nil, fmt.Errorf("unknown announcement kind %v", a.Kind)
+	}
+	return a, nil
+}
+
+// Announce publishes that plugin was registered or yanked here.
+func (a *Announcer) Announce(ctx context.Context, kind pb.PluginAnnouncement_Kind, plugin *pb.Plugin) error {
+	data, err := proto.Marshal(&pb.PluginAnnouncement{
+		Kind:      kind,
+		Plugin:    plugin,
+		Timestamp: time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	return a.topic.Publish(ctx, data)
+}
+
+// Run passes announcements from other registries to ingest until ctx is
+// cancelled.
+func (a *Announcer) Run(ctx context.Context, ingest IngestFunc) {
+	for {
+		msg, err := a.sub.Next(ctx)
+		if err != nil {
+			// Cancelled, or the subscription was closed.
+			return
+		}
+		if msg.GetFrom() == a.self {
+			continue
+		}
+		ann, err := decodeAnnouncement(msg.Data)
+		if err != nil {
+			continue
+		}
+		ingest(msg.GetFrom(), ann)
+	}
+}
+
+// Close leaves the topic.
+func (a *Announcer) Close() error {
+	a.sub.Cancel()
+	return a.topic.Close()
+}
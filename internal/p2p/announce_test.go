@@ -0,0 +1,78 @@
+package p2p
+
+import (
+	"testing"
+
+	"spacecore_registry/pb"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeAnnouncement(t *testing.T) {
+	plugin := &pb.Plugin{Name: "acme/tool", Version: "1.0.0"}
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{"registered", mustMarshal(t, &pb.PluginAnnouncement{Kind: pb.PluginAnnouncement_KIND_REGISTERED, Plugin: plugin}), false},
+		{"yanked", mustMarshal(t, &pb.PluginAnnouncement{Kind: pb.PluginAnnouncement_KIND_YANKED, Plugin: plugin}), false},
+		{"no plugin", mustMarshal(t, &pb.PluginAnnouncement{Kind: pb.PluginAnnouncement_KIND_REGISTERED}), true},
+		{"unknown kind", mustMarshal(t, &pb.PluginAnnouncement{Plugin: plugin}), true},
+		{"garbage", []byte{0xff, 0xff}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeAnnouncement(tt.data)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Errorf("decodeAnnouncement() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func mustMarshal(t *testing.T, m proto.Message) []byte {
+	t.Helper()
+	data, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestFederationIngest(t *testing.T) {
+	f := newTestFederation(func(*pb.Plugin) error { return nil })
+	remote := peer.ID("remote")
+	announce := func(version string, yanked bool, reason string) {
+		f.Ingest(remote, &pb.PluginAnnouncement{
+			Kind:   pb.PluginAnnouncement_KIND_REGISTERED,
+			Plugin: &pb.Plugin{Name: "acme/tool", Version: version, Yanked: yanked, YankReason: reason, Downloads: 3},
+		})
+	}
+
+	announce("1.0.0", false, "")
+	announce("1.1.0", false, "")
+	plugins := f.Plugins()
+	if len(plugins) != 2 {
+		t.Fatalf("got %d plugins, want 2", len(plugins))
+	}
+	for _, p := range plugins {
+		if p.Registry != remote.String() || p.Downloads != 0 {
+			t.Errorf("ingested record %v: want registry %s and unsigned fields cleared", p, remote)
+		}
+	}
+
+	announce("1.0.0", true, "broken")
+	// A replayed registration must not undo the yank.
+	announce("1.0.0", false, "")
+	plugins = f.Plugins()
+	if len(plugins) != 2 {
+		t.Fatalf("got %d plugins after re-announcing, want 2", len(plugins))
+	}
+	for _, p := range plugins {
+		if p.Version == "1.0.0" && (!p.Yanked || p.YankReason != "broken") {
+			t.Errorf("1.0.0 after replay: yanked %v, reason %q", p.Yanked, p.YankReason)
+		}
+	}
+}
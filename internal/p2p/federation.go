@@ -0,0 +1,241 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"spacecore_registry/pb"
+
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"google.golang.org/protobuf/proto"
+)
+
+// IndexProtocol serves a registry's plugin index to federated peers as a JSON
+// array of pb.Plugin.
+const IndexProtocol protocol.ID = "/spacecore-registry/index/1.0.0"
+
+const (
+	indexTimeout = 30 * time.Second
+	// maxIndexSize bounds how much a peer may send us.
+	maxIndexSize = 32 << 20
+	// staleRounds is how many discovery rounds a peer's index is kept after
+	// it was last fetched.
+	staleRounds = 3
+)
+
+// IndexFunc returns the records a registry serves to its peers.
+type IndexFunc func(ctx context.Context) ([]*pb.Plugin, error)
+
+// ValidateFunc rejects records from peers that should not be trusted, such as
+// those with a bad publisher signature.
+type ValidateFunc func(*pb.Plugin) error
+
+// Federation finds other registries on the rendezvous and keeps a copy of
+// their plugin indexes.
+type Federation struct {
+	host     host.Host
+	dht      *dht.IpfsDHT
+	index    IndexFunc
+	validate ValidateFunc
+
+	mu    sync.RWMutex
+	peers map[peer.ID]*peerIndex
+}
+
+type peerIndex struct {
+	plugins []*pb.Plugin
+	fetched time.Time
+}
+
+// NewFederation serves index on h and returns a Federation ready to Run.
+func NewFederation(h host.Host, kadDHT *dht.IpfsDHT, index IndexFunc, validate ValidateFunc) *Federation {
+	f := &Federation{
+		host:     h,
+		dht:      kadDHT,
+		index:    index,
+		validate: validate,
+		peers:    make(map[peer.ID]*peerIndex),
+	}
+	h.SetStreamHandler(IndexProtocol, f.serveIndex)
+	return f
+}
+
+func (f *Federation) serveIndex(s network.Stream) {
+	defer s.Close()
+	s.SetDeadline(time.Now().Add(indexTimeout))
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+	plugins, err := f.index(ctx)
+	if err != nil {
+		log.Printf("failed to build index for %s: %v", s.Conn().RemotePeer(), err)
+		s.Reset()
+		return
+	}
+	if err := json.NewEncoder(s).Encode(plugins); err != nil {
+		log.Printf("failed to send index to %s: %v", s.Conn().RemotePeer(), err)
+		s.Reset()
+	}
+}
+
+// FetchIndex requests the plugin index of the registry at p.
+func FetchIndex(ctx context.Context, h host.Host, p peer.ID) ([]*pb.Plugin, error) {
+	ctx, cancel := context.WithTimeout(ctx, indexTimeout)
+	defer cancel()
+	s, err := h.NewStream(ctx, p, IndexProtocol)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	s.SetDeadline(time.Now().Add(indexTimeout))
+	if err := s.CloseWrite(); err != nil {
+		return nil, err
+	}
+	var plugins []*pb.Plugin
+	if err := json.NewDecoder(io.LimitReader(s, maxIndexSize)).Decode(&plugins); err != nil {
+		return nil, fmt.Errorf("failed to read index from %s: %w", p, err)
+	}
+	return plugins, nil
+}
+
+// Run advertises this registry on the rendezvous and refreshes peer indexes
+// every interval until ctx is cancelled.
+func (f *Federation) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		f.refresh(ctx, interval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (f *Federation) refresh(ctx context.Context, interval time.Duration) {
+	if err := Advertise(ctx, f.host, f.dht); err != nil {
+		log.Printf("failed to advertise registry: %v", err)
+	}
+	discoverCtx, cancel := context.WithTimeout(ctx, indexTimeout)
+	peers, err := DiscoverPeers(discoverCtx, f.dht)
+	cancel()
+	if err != nil {
+		log.Printf("failed to discover registries: %v", err)
+	}
+	for _, info := range peers {
+		if info.ID == f.host.ID() {
+			continue
+		}
+		if err := f.host.Connect(ctx, info); err != nil {
+			log.Printf("failed to connect to registry %s: %v", info.ID, err)
+			continue
+		}
+		plugins, err := FetchIndex(ctx, f.host, info.ID)
+		if err != nil {
+			log.Printf("failed to fetch index from %s: %v", info.ID, err)
+			continue
+		}
+		f.store(info.ID, plugins)
+	}
+	f.expire(time.Now().Add(-staleRounds * interval))
+}
+
+// store keeps the valid records of a peer's index, tagged with its peer ID.
+func (f *Federation) store(p peer.ID, plugins []*pb.Plugin) {
+	valid := make([]*pb.Plugin, 0, len(plugins))
+	for _, plugin := range plugins {
+		if err := f.validate(plugin); err != nil {
+			log.Printf("dropping %s:%s from %s: %v", plugin.Name, plugin.Version, p, err)
+			continue
+		}
+		stripUnsigned(plugin)
+		plugin.Registry = p.String()
+		valid = append(valid, plugin)
+	}
+	f.mu.Lock()
+	f.peers[p] = &peerIndex{plugins: valid, fetched: time.Now()}
+	f.mu.Unlock()
+	log.Printf("Fetched %d plugins from registry %s", len(valid), p)
+}
+
+// Ingest records a plugin announced by registry p, so it shows up in
+// federated results before the next index fetch.
+func (f *Federation) Ingest(p peer.ID, a *pb.PluginAnnouncement) {
+	plugin := proto.Clone(a.Plugin).(*pb.Plugin)
+	stripUnsigned(plugin)
+	plugin.Registry = p.String()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	idx := f.peers[p]
+	if idx == nil {
+		idx = &peerIndex{fetched: time.Now()}
+		f.peers[p] = idx
+	}
+	for i, existing := range idx.plugins {
+		if existing.Name == plugin.Name && existing.Version == plugin.Version {
+			// Yanks are final; a late or replayed registration must not undo one.
+			if existing.Yanked && !plugin.Yanked {
+				plugin.Yanked, plugin.YankReason = true, existing.YankReason
+			}
+			idx.plugins[i] = plugin
+			return
+		}
+	}
+	idx.plugins = append(idx.plugins, plugin)
+}
+
+// stripUnsigned clears what a peer reports about its own copy of a plugin:
+// pinning, availability, downloads and the digests it computed. None of it
+// is covered by the publisher signature, so it can't be passed on as fact.
+// Yank status is kept, as asserted by the peer, so yanked versions aren't
+// offered as the latest.
+func stripUnsigned(plugin *pb.Plugin) {
+	plugin.Pins = nil
+	plugin.Replicas = 0
+	plugin.MinReplicas = 0
+	plugin.Available = false
+	plugin.Downloads = 0
+	plugin.Sha256 = ""
+	plugin.Size = 0
+	for _, artifact := range plugin.Artifacts {
+		artifact.Sha256 = ""
+		artifact.Size = 0
+	}
+}
+
+func (f *Federation) expire(before time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for p, idx := range f.peers {
+		if idx.fetched.Before(before) {
+			delete(f.peers, p)
+		}
+	}
+}
+
+// Plugins returns copies of every record known from federated registries.
+func (f *Federation) Plugins() []*pb.Plugin {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	var plugins []*pb.Plugin
+	for _, idx := range f.peers {
+		for _, plugin := range idx.plugins {
+			plugins = append(plugins, proto.Clone(plugin).(*pb.Plugin))
+		}
+	}
+	return plugins
+}
+
+// Close stops serving the index.
+func (f *Federation) Close() {
+	f.host.RemoveStreamHandler(IndexProtocol)
+}
@@ -0,0 +1,71 @@
+package p2p
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"spacecore_registry/pb"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+func newTestFederation(validate ValidateFunc) *Federation {
+	return &Federation{validate: validate, peers: make(map[peer.ID]*peerIndex)}
+}
+
+func TestFederationStore(t *testing.T) {
+	f := newTestFederation(func(p *pb.Plugin) error {
+		if p.Signature == nil {
+			return errors.New("unsigned")
+		}
+		return nil
+	})
+	remote := peer.ID("remote")
+	f.store(remote, []*pb.Plugin{
+		{
+			Name: "acme/tool", Version: "1.0.0", Signature: []byte("sig"),
+			Pins: []*pb.PinStatus{{Provider: "pinata"}}, Replicas: 2, MinReplicas: 1, Available: true,
+			Downloads: 10, Sha256: "abc", Size: 5, Yanked: true,
+			Artifacts: []*pb.Artifact{{Cid: "/ipfs/a", Sha256: "abc", Size: 5}},
+		},
+		{Name: "acme/unsigned", Version: "1.0.0"},
+	})
+
+	plugins := f.Plugins()
+	if len(plugins) != 1 {
+		t.Fatalf("got %d plugins, want the signed one only", len(plugins))
+	}
+	p := plugins[0]
+	if p.Registry != remote.String() {
+		t.Errorf("registry = %q, want %q", p.Registry, remote)
+	}
+	if p.Pins != nil || p.Replicas != 0 || p.MinReplicas != 0 || p.Available || p.Downloads != 0 || p.Sha256 != "" || p.Size != 0 {
+		t.Errorf("unsigned fields kept: %v", p)
+	}
+	if a := p.Artifacts[0]; a.Sha256 != "" || a.Size != 0 {
+		t.Errorf("unsigned artifact fields kept: %v", a)
+	}
+	if !p.Yanked {
+		t.Error("yank status was dropped")
+	}
+
+	// Plugins hands out copies.
+	p.Name = "changed"
+	if f.Plugins()[0].Name != "acme/tool" {
+		t.Error("changing a returned record changed the stored one")
+	}
+}
+
+func TestFederationExpire(t *testing.T) {
+	f := newTestFederation(func(*pb.Plugin) error { return nil })
+	f.store("old", []*pb.Plugin{{Name: "acme/old", Version: "1.0.0"}})
+	f.peers["old"].fetched = time.Now().Add(-time.Hour)
+	f.store("new", []*pb.Plugin{{Name: "acme/new", Version: "1.0.0"}})
+
+	f.expire(time.Now().Add(-time.Minute))
+	plugins := f.Plugins()
+	if len(plugins) != 1 || plugins[0].Name != "acme/new" {
+		t.Errorf("plugins after expiry = %v, want only acme/new", plugins)
+	}
+}
@@ -2,10 +2,19 @@ package p2p
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	libp2p "github.com/libp2p/go-libp2p"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 
@@ -13,24 +22,109 @@ import (
 	"github.com/libp2p/go-libp2p/p2p/discovery/routing"
 )
 
-func SetupHost(ctx context.Context) (host.Host, *dht.IpfsDHT, error) {
+// bootstrapTimeout bounds the initial dials to DHT bootstrap peers.
+const bootstrapTimeout = 30 * time.Second
+
+// LoadIdentity reads the host key from path, or generates an Ed25519 key and
+// saves it there if the file doesn't exist yet.
+func LoadIdentity(path string) (crypto.PrivKey, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		key, err := crypto.UnmarshalPrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read identity %s: %w", path, err)
+		}
+		return key, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read identity: %w", err)
+	}
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate identity: %w", err)
+	}
+	data, err = crypto.MarshalPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	// O_EXCL keeps a concurrently started registry's key from being replaced.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save identity: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to save identity: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to save identity: %w", err)
+	}
+	log.Printf("Generated libp2p identity in %s", path)
+	return key, nil
+}
+
+// NewHost creates the registry's libp2p host with the given identity.
+func NewHost(listenAddrs []string, key crypto.PrivKey) (host.Host, error) {
 	h, err := libp2p.New(
-		libp2p.ListenAddrStrings("/ip4/0.0.0.0/tcp/0"),
+		libp2p.Identity(key),
+		libp2p.ListenAddrStrings(listenAddrs...),
 	)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create libp2p host: %w", err)
+		return nil, fmt.Errorf("failed to create libp2p host: %w", err)
+	}
+	return h, nil
+}
+
+// NewDHT joins the Kademlia DHT through h, seeding its routing table from the
+// default IPFS bootstrap peers and the given /p2p multiaddrs.
+func NewDHT(ctx context.Context, h host.Host, bootstrapAddrs []string) (*dht.IpfsDHT, error) {
+	bootstrapPeers := dht.GetDefaultBootstrapPeerAddrInfos()
+	for _, addr := range bootstrapAddrs {
+		info, err := peer.AddrInfoFromString(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bootstrap peer %q: %w", addr, err)
+		}
+		bootstrapPeers = append(bootstrapPeers, *info)
 	}
 
-	dht, err := dht.New(ctx, h)
+	kadDHT, err := dht.New(ctx, h, dht.BootstrapPeers(bootstrapPeers...))
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create DHT: %w", err)
+		return nil, fmt.Errorf("failed to create DHT: %w", err)
 	}
 
-	if err := dht.Bootstrap(ctx); err != nil {
-		return nil, nil, fmt.Errorf("failed to bootstrap DHT: %w", err)
+	if connected := connectPeers(ctx, h, bootstrapPeers); connected == 0 {
+		log.Printf("could not reach any of %d DHT bootstrap peers", len(bootstrapPeers))
+	}
+	if err := kadDHT.Bootstrap(ctx); err != nil {
+		kadDHT.Close()
+		return nil, fmt.Errorf("failed to bootstrap DHT: %w", err)
 	}
 
-	return h, dht, nil
+	return kadDHT, nil
+}
+
+// connectPeers dials peers in parallel and returns how many it reached.
+func connectPeers(ctx context.Context, h host.Host, peers []peer.AddrInfo) int {
+	ctx, cancel := context.WithTimeout(ctx, bootstrapTimeout)
+	defer cancel()
+	var connected atomic.Int32
+	var wg sync.WaitGroup
+	for _, info := range peers {
+		wg.Add(1)
+		go func(info peer.AddrInfo) {
+			defer wg.Done()
+			if err := h.Connect(ctx, info); err != nil {
+				log.Printf("failed to connect to bootstrap peer %s: %v", info.ID, err)
+				return
+			}
+			connected.Add(1)
+		}(info)
+	}
+	wg.Wait()
+	return int(connected.Load())
 }
 
 func Advertise(ctx context.Context, h host.Host, dht *dht.IpfsDHT) error {
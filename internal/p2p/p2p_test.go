@@ -0,0 +1,44 @@
+package p2p
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+func TestLoadIdentity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "identity.key")
+	key, err := LoadIdentity(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("identity file mode = %o, want 600", perm)
+	}
+
+	again, err := LoadIdentity(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _ := peer.IDFromPrivateKey(key)
+	second, _ := peer.IDFromPrivateKey(again)
+	if first != second {
+		t.Errorf("peer ID changed from %s to %s on reload", first, second)
+	}
+}
+
+func TestLoadIdentityCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "identity.key")
+	if err := os.WriteFile(path, []byte("not a key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadIdentity(path); err == nil {
+		t.Error("LoadIdentity accepted a corrupt key file")
+	}
+}
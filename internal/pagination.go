@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"context"
+	"encoding/base64"
+	"slices"
+	"sort"
+	"strings"
+
+	"spacecore_registry/internal/store"
+	"spacecore_registry/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+// pageLimit applies the default and maximum to a requested page size.
+func pageLimit(pageSize int32) int {
+	if pageSize <= 0 {
+		return defaultPageSize
+	}
+	return min(int(pageSize), maxPageSize)
+}
+
+// encodePageToken turns the last record of a page into an opaque token. The
+// registry is included so a page can end on a federated record.
+func encodePageToken(plugin *pb.Plugin) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(plugin.Name + "\x00" + plugin.Version + "\x00" + plugin.Registry))
+}
+
+// decodePageToken returns the position of the last record of the previous
+// page, and the registry it came from if it was federated.
+func decodePageToken(token string) (store.PageCursor, string, error) {
+	if token == "" {
+		return store.PageCursor{}, "", nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return store.PageCursor{}, "", status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	parts := strings.SplitN(string(raw), "\x00", 3)
+	if len(parts) < 2 || parts[0] == "" {
+		return store.PageCursor{}, "", status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	var registry string
+	if len(parts) == 3 {
+		registry = parts[2]
+	}
+	return store.PageCursor{Name: parts[0], Version: parts[1]}, registry, nil
+}
+
+// pageLess orders listed records by name and then version, as stores list
+// them, with a local record before federated ones of the same version.
+func pageLess(a, b *pb.Plugin) bool {
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	if a.Version != b.Version {
+		return store.VersionLess(a.Version, b.Version)
+	}
+	return a.Registry < b.Registry
+}
+
+// listPlugins returns one page of locally registered plugins merged with the
+// given federated records.
+func (s *pluginRegistryServer) listPlugins(ctx context.Context, pageSize int32, pageToken string, federated []*pb.Plugin) (*pb.DiscoverPluginsResponse, error) {
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+	size := pageLimit(pageSize)
+	cursor, registry, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ask for one extra to learn whether another page follows. Local records
+	// of the cursor's version sort before any federated one, so whichever
+	// the last page ended on, the store's next page starts after it.
+	local, total, err := s.store.ListPluginsPage(ctx, cursor, size+1)
+	if err != nil {
+		return nil, err
+	}
+	total += len(federated)
+	if pageToken != "" {
+		last := &pb.Plugin{Name: cursor.Name, Version: cursor.Version, Registry: registry}
+		federated = slices.DeleteFunc(federated, func(p *pb.Plugin) bool { return !pageLess(last, p) })
+	}
+	sort.Slice(federated, func(i, j int) bool { return pageLess(federated[i], federated[j]) })
+
+	plugins := make([]*pb.Plugin, 0, size+1)
+	for len(plugins) <= size && (len(local) > 0 || len(federated) > 0) {
+		if len(federated) == 0 || len(local) > 0 && pageLess(local[0], federated[0]) {
+			plugins, local = append(plugins, local[0]), local[1:]
+		} else {
+			plugins, federated = append(plugins, federated[0]), federated[1:]
+		}
+	}
+
+	resp := &pb.DiscoverPluginsResponse{TotalCount: int32(total)}
+	if len(plugins) > size {
+		plugins = plugins[:size]
+		resp.NextPageToken = encodePageToken(plugins[size-1])
+	}
+	resp.Plugins = plugins
+	return resp, nil
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"spacecore_registry/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPageToken(t *testing.T) {
+	for _, p := range []*pb.Plugin{
+		{Name: "acme/tool", Version: "1.2.0"},
+		{Name: "acme/tool", Version: "1.2.0", Registry: "12D3KooWPeer"},
+	} {
+		cursor, registry, err := decodePageToken(encodePageToken(p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cursor.Name != p.Name || cursor.Version != p.Version || registry != p.Registry {
+			t.Errorf("round trip of %s:%s@%q = %+v, %q", p.Name, p.Version, p.Registry, cursor, registry)
+		}
+	}
+
+	legacy := base64.RawURLEncoding.EncodeToString([]byte("acme/tool\x001.0.0"))
+	if cursor, registry, err := decodePageToken(legacy); err != nil || cursor.Version != "1.0.0" || registry != "" {
+		t.Errorf("token without a registry = %+v, %q, %v", cursor, registry, err)
+	}
+
+	for _, token := range []string{"!!!", base64.RawURLEncoding.EncodeToString([]byte("no-separator")), base64.RawURLEncoding.EncodeToString([]byte("\x001.0.0"))} {
+		if _, _, err := decodePageToken(token); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("decodePageToken(%q) = %v, want InvalidArgument", token, err)
+		}
+	}
+}
+
+// listAll pages through listPlugins and returns every record as
+// "name version registry", along with the number of pages.
+func listAll(t *testing.T, s *pluginRegistryServer, pageSize int32, federated func() []*pb.Plugin) ([]string, int) {
+	t.Helper()
+	var got []string
+	token := ""
+	for pages := 1; ; pages++ {
+		resp, err := s.listPlugins(context.Background(), pageSize, token, federated())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Plugins) > int(pageSize) {
+			t.Fatalf("page %d has %d records, more than %d", pages, len(resp.Plugins), pageSize)
+		}
+		for _, p := range resp.Plugins {
+			got = append(got, p.Name+" "+p.Version+" "+p.Registry)
+		}
+		if resp.NextPageToken == "" {
+			return got, pages
+		}
+		if pages > 20 {
+			t.Fatal("pagination does not end")
+		}
+		token = resp.NextPageToken
+	}
+}
+
+func TestListPluginsFederated(t *testing.T) {
+	s := newTestServer(t,
+		&pb.Plugin{Name: "a", Version: "1.0.0", Cid: "/ipfs/a-1.0.0"},
+		&pb.Plugin{Name: "a", Version: "1.10.0", Cid: "/ipfs/a-1.10.0"},
+		&pb.Plugin{Name: "c", Version: "2.0.0", Cid: "/ipfs/c-2.0.0"},
+	)
+	federated := func() []*pb.Plugin {
+		return []*pb.Plugin{
+			{Name: "d", Version: "1.0.0", Registry: "peer1"},
+			{Name: "a", Version: "1.10.0", Registry: "peer2"},
+			{Name: "b", Version: "0.1.0", Registry: "peer1"},
+			{Name: "a", Version: "1.9.0", Registry: "peer1"},
+			{Name: "a", Version: "1.10.0", Registry: "peer1"},
+		}
+	}
+	want := []string{
+		"a 1.0.0 ",
+		"a 1.9.0 peer1",
+		"a 1.10.0 ",
+		"a 1.10.0 peer1",
+		"a 1.10.0 peer2",
+		"b 0.1.0 peer1",
+		"c 2.0.0 ",
+		"d 1.0.0 peer1",
+	}
+
+	for _, size := range []int32{1, 2, 3, 8, 100} {
+		got, pages := listAll(t, s, size, federated)
+		if len(got) != len(want) {
+			t.Fatalf("page size %d: got %q, want %q", size, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("page size %d: record %d = %q, want %q", size, i, got[i], want[i])
+			}
+		}
+		if wantPages := (len(want) + int(size) - 1) / int(size); pages != wantPages {
+			t.Errorf("page size %d: got %d pages, want %d", size, pages, wantPages)
+		}
+	}
+
+	resp, err := s.listPlugins(context.Background(), 2, "", federated())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.TotalCount != int32(len(want)) {
+		t.Errorf("total count = %d, want %d", resp.TotalCount, len(want))
+	}
+}
+
+func TestListPluginsLocal(t *testing.T) {
+	s := newTestServer(t,
+		&pb.Plugin{Name: "a", Version: "1.0.0", Cid: "/ipfs/a-1.0.0"},
+		&pb.Plugin{Name: "b", Version: "1.0.0", Cid: "/ipfs/b-1.0.0"},
+	)
+	none := func() []*pb.Plugin { return nil }
+	got, pages := listAll(t, s, 1, none)
+	if len(got) != 2 || pages != 2 {
+		t.Errorf("got %q in %d pages, want both versions in 2", got, pages)
+	}
+	if _, err := s.listPlugins(context.Background(), -1, "", nil); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("negative page size: %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,50 @@
+package pinning
+
+import (
+	"context"
+
+	"spacecore_registry/pb"
+
+	"github.com/ipfs/boxo/path"
+	"github.com/ipfs/go-cid"
+)
+
+// LocalNode is the part of the registry's IPFS node the local pinner needs.
+type LocalNode interface {
+	Pin(ctx context.Context, p path.ImmutablePath) error
+	IsPinned(ctx context.Context, p path.ImmutablePath) (bool, error)
+}
+
+// LocalPinner counts the registry's own IPFS node as a replica.
+type LocalPinner struct {
+	node LocalNode
+}
+
+func NewLocalPinner(node LocalNode) *LocalPinner {
+	return &LocalPinner{node: node}
+}
+
+func (p *LocalPinner) Name() string { return ProviderLocal }
+
+func (p *LocalPinner) Pin(ctx context.Context, c cid.Cid, name string) (pb.PinState, string, error) {
+	if err := p.node.Pin(ctx, path.FromCid(c)); err != nil {
+		return pb.PinState_PIN_STATE_FAILED, "", err
+	}
+	return pb.PinState_PIN_STATE_PINNED, "", nil
+}
+
+// Ping always succeeds; the IPFS node is checked on its own.
+func (p *LocalPinner) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (p *LocalPinner) Status(ctx context.Context, c cid.Cid, requestID string) (pb.PinState, error) {
+	pinned, err := p.node.IsPinned(ctx, path.FromCid(c))
+	if err != nil {
+		return pb.PinState_PIN_STATE_UNSPECIFIED, err
+	}
+	if !pinned {
+		return pb.PinState_PIN_STATE_UNSPECIFIED, nil
+	}
+	return pb.PinState_PIN_STATE_PINNED, nil
+}
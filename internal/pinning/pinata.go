@@ -0,0 +1,179 @@
+package pinning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"spacecore_registry/internal/metrics"
+	"spacecore_registry/pb"
+
+	"github.com/ipfs/go-cid"
+)
+
+const (
+	pinataPinByHashURL = "https://api.pinata.cloud/pinning/pinByHash"
+	pinataPinListURL   = "https://api.pinata.cloud/data/pinList"
+	pinataPinJobsURL   = "https://api.pinata.cloud/pinning/pinJobs"
+	pinataTestAuthURL  = "https://api.pinata.cloud/data/testAuthentication"
+)
+
+// PinataPinner pins through Pinata's pinByHash endpoint.
+type PinataPinner struct {
+	jwt       string
+	hostNodes []string
+	client    *http.Client
+}
+
+func NewPinataPinner(jwt string, hostNodes []string) *PinataPinner {
+	return &PinataPinner{
+		jwt:       jwt,
+		hostNodes: hostNodes,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (p *PinataPinner) Name() string { return ProviderPinata }
+
+type pinataPinRequest struct {
+	HashToPin      string         `json:"hashToPin"`
+	PinataMetadata map[string]any `json:"pinataMetadata,omitempty"`
+	PinataOptions  map[string]any `json:"pinataOptions,omitempty"`
+}
+
+type pinataPinResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func (p *PinataPinner) Pin(ctx context.Context, c cid.Cid, name string) (pb.PinState, string, error) {
+	body := pinataPinRequest{
+		HashToPin:      c.String(),
+		PinataMetadata: map[string]any{"name": name},
+	}
+	if len(p.hostNodes) > 0 {
+		// Hint where Pinata can fetch the content from.
+		body.PinataOptions = map[string]any{"hostNodes": p.hostNodes}
+	}
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return pb.PinState_PIN_STATE_FAILED, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, pinataPinByHashURL, bytes.NewReader(jsonBody))
+	if err != nil {
+		return pb.PinState_PIN_STATE_FAILED, "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.jwt)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.do(req, "pin_by_hash")
+	if err != nil {
+		return pb.PinState_PIN_STATE_FAILED, "", fmt.Errorf("pinata request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return pb.PinState_PIN_STATE_FAILED, "", fmt.Errorf("pinata returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	var pinResp pinataPinResponse
+	if err := json.NewDecoder(resp.Body).Decode(&pinResp); err != nil {
+		return pb.PinState_PIN_STATE_FAILED, "", fmt.Errorf("failed to decode pinata response: %w", err)
+	}
+	// pinByHash only queues the pin; Pinata searches the network for the
+	// content afterwards.
+	switch pinResp.Status {
+	case "pinned":
+		return pb.PinState_PIN_STATE_PINNED, pinResp.ID, nil
+	case "prechecking", "retrieving", "searching":
+		return pb.PinState_PIN_STATE_PINNING, pinResp.ID, nil
+	default:
+		return pb.PinState_PIN_STATE_QUEUED, pinResp.ID, nil
+	}
+}
+
+type pinataCount struct {
+	Count int `json:"count"`
+}
+
+// Status checks Pinata's pin list first, then its queue of pin-by-hash jobs
+// that are still searching for the content. Both are looked up by CID, so
+// requestID is not needed.
+func (p *PinataPinner) Status(ctx context.Context, c cid.Cid, requestID string) (pb.PinState, error) {
+	pinned, err := p.count(ctx, "pin_list", pinataPinListURL, url.Values{"hashContains": {c.String()}, "status": {"pinned"}})
+	if err != nil {
+		return pb.PinState_PIN_STATE_UNSPECIFIED, err
+	}
+	if pinned > 0 {
+		return pb.PinState_PIN_STATE_PINNED, nil
+	}
+	jobs, err := p.count(ctx, "pin_jobs", pinataPinJobsURL, url.Values{"ipfs_pin_hash": {c.String()}})
+	if err != nil {
+		return pb.PinState_PIN_STATE_UNSPECIFIED, err
+	}
+	if jobs > 0 {
+		return pb.PinState_PIN_STATE_PINNING, nil
+	}
+	return pb.PinState_PIN_STATE_UNSPECIFIED, nil
+}
+
+func (p *PinataPinner) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pinataTestAuthURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.jwt)
+
+	resp, err := p.do(req, "test_authentication")
+	if err != nil {
+		return fmt.Errorf("pinata request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("pinata returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+func (p *PinataPinner) count(ctx context.Context, operation, endpoint string, query url.Values) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.jwt)
+
+	resp, err := p.do(req, operation)
+	if err != nil {
+		return 0, fmt.Errorf("pinata request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, fmt.Errorf("pinata returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	var result pinataCount
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode pinata response: %w", err)
+	}
+	return result.Count, nil
+}
+
+// do sends req and records the outcome of the call for operation.
+func (p *PinataPinner) do(req *http.Request, operation string) (*http.Response, error) {
+	resp, err := p.client.Do(req)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	metrics.PinataRequests.WithLabelValues(operation, code).Inc()
+	return resp, err
+}
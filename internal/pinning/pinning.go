@@ -0,0 +1,130 @@
+package pinning
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spacecore_registry/pb"
+
+	"github.com/ipfs/go-cid"
+)
+
+// Pinner asks a remote provider to keep a CID available.
+type Pinner interface {
+	// Name identifies the provider in pin statuses.
+	Name() string
+	// Pin requests that c be pinned. A nil error means the provider accepted
+	// the request; the returned state says how far it got, and requestID
+	// identifies the request if the provider tracks one.
+	Pin(ctx context.Context, c cid.Cid, name string) (state pb.PinState, requestID string, err error)
+	// Status reports whether the provider currently holds c, or is working on
+	// it. With a requestID from Pin, only that request is checked. It returns
+	// PIN_STATE_UNSPECIFIED when the provider has no record of it.
+	Status(ctx context.Context, c cid.Cid, requestID string) (pb.PinState, error)
+	// Ping checks that the provider can be reached and accepts the
+	// registry's credentials.
+	Ping(ctx context.Context) error
+}
+
+// Provider names accepted by Open. Any other name refers to an entry in
+// Options.Services.
+const (
+	ProviderPinata = "pinata"
+	ProviderLocal  = "local"
+	// ProviderNone disables remote pinning, including the Pinata default. It
+	// adds no pinner and can't be combined with other providers.
+	ProviderNone = "none"
+	// ProviderService is the default name for a pinning service.
+	ProviderService = "pinning-service"
+)
+
+// ServiceOptions describes one IPFS Pinning Service API endpoint, such as a
+// hosted provider or a self-hosted ipfs-cluster.
+type ServiceOptions struct {
+	Name     string
+	Endpoint string
+	Token    string
+	// Origins are multiaddrs the service can fetch content from.
+	Origins []string
+}
+
+// Options configures the pinners returned by Open.
+type Options struct {
+	// Providers lists the providers to pin to, in order.
+	Providers []string
+
+	PinataJWT       string
+	PinataHostNodes []string
+
+	Services []ServiceOptions
+
+	// Local is the registry's own IPFS node, used by the "local" provider.
+	Local LocalNode
+}
+
+// Open builds the configured pinners. An empty provider list pins nowhere
+// beyond the local IPFS node.
+func Open(opts Options) ([]Pinner, error) {
+	var pinners []Pinner
+	seen := make(map[string]bool)
+	for _, provider := range opts.Providers {
+		provider = strings.TrimSpace(provider)
+		if seen[provider] {
+			return nil, fmt.Errorf("pinning provider %q listed twice", provider)
+		}
+		seen[provider] = true
+
+		switch provider {
+		case ProviderNone:
+			if len(opts.Providers) > 1 {
+				return nil, fmt.Errorf("pinning provider %q can't be combined with other providers", ProviderNone)
+			}
+		case ProviderLocal:
+			if opts.Local == nil {
+				return nil, fmt.Errorf("local pinner requires an IPFS node")
+			}
+			pinners = append(pinners, NewLocalPinner(opts.Local))
+		case ProviderPinata:
+			if opts.PinataJWT == "" {
+				return nil, fmt.Errorf("pinata pinner requires a JWT")
+			}
+			pinners = append(pinners, NewPinataPinner(opts.PinataJWT, opts.PinataHostNodes))
+		default:
+			service, ok := findService(opts.Services, provider)
+			if !ok {
+				return nil, fmt.Errorf("unknown pinning provider %q", provider)
+			}
+			if service.Endpoint == "" {
+				return nil, fmt.Errorf("pinning service %q requires an endpoint", provider)
+			}
+			pinners = append(pinners, NewServicePinner(service.Name, service.Endpoint, service.Token, service.Origins))
+		}
+	}
+	return pinners, nil
+}
+
+func findService(services []ServiceOptions, name string) (ServiceOptions, bool) {
+	for _, service := range services {
+		if service.Name == name {
+			return service, true
+		}
+	}
+	return ServiceOptions{}, false
+}
+
+// stateRank orders states by how close they are to pinned.
+func stateRank(state pb.PinState) int {
+	switch state {
+	case pb.PinState_PIN_STATE_FAILED:
+		return 1
+	case pb.PinState_PIN_STATE_QUEUED:
+		return 2
+	case pb.PinState_PIN_STATE_PINNING:
+		return 3
+	case pb.PinState_PIN_STATE_PINNED:
+		return 4
+	default:
+		return 0
+	}
+}
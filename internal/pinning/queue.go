@@ -0,0 +1,415 @@
+package pinning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"spacecore_registry/internal/store"
+	"spacecore_registry/pb"
+
+	"github.com/ipfs/go-cid"
+)
+
+const (
+	pollInterval = 5 * time.Second
+	baseBackoff  = 10 * time.Second
+	maxBackoff   = 30 * time.Minute
+	maxAttempts  = 8
+	pinTimeout   = time.Minute
+	// pinWorkers is the number of jobs processed at once.
+	pinWorkers = 4
+
+	// Accepted pins are polled from basePollDelay, doubling up to
+	// maxPollDelay, and fail if the provider hasn't finished within
+	// maxPinWait.
+	basePollDelay = 5 * time.Second
+	maxPollDelay  = 5 * time.Minute
+	maxPinWait    = 24 * time.Hour
+)
+
+// Queue pins plugin versions to remote providers in the background. Jobs are
+// persisted in the metadata store and retried with exponential backoff. Once
+// a provider accepts a pin, the job stays queued and polls the provider until
+// it is pinned or has failed. The outcome is recorded in each plugin's pin
+// statuses along with the replication level it reached.
+type Queue struct {
+	store   store.MetadataStore
+	pinners map[string]Pinner
+	order   []string
+	wake    chan struct{}
+	// defaultReplicas applies to namespaces without their own policy.
+	defaultReplicas int
+	// reconciled is the last plugin version checked by Reconcile, which
+	// resumes after it.
+	reconciled string
+}
+
+func NewQueue(metadataStore store.MetadataStore, pinners []Pinner, defaultReplicas int) (*Queue, error) {
+	if defaultReplicas < 0 || defaultReplicas > len(pinners) {
+		return nil, fmt.Errorf("default replication of %d needs between 0 and %d pinning providers", defaultReplicas, len(pinners))
+	}
+	q := &Queue{
+		store:           metadataStore,
+		pinners:         make(map[string]Pinner, len(pinners)),
+		wake:            make(chan struct{}, 1),
+		defaultReplicas: defaultReplicas,
+	}
+	for _, p := range pinners {
+		q.pinners[p.Name()] = p
+		q.order = append(q.order, p.Name())
+	}
+	return q, nil
+}
+
+// Providers returns the number of configured pinning providers, the upper
+// bound for any replication policy.
+func (q *Queue) Providers() int {
+	return len(q.order)
+}
+
+// Pinners returns the configured providers in order.
+func (q *Queue) Pinners() []Pinner {
+	pinners := make([]Pinner, 0, len(q.order))
+	for _, name := range q.order {
+		pinners = append(pinners, q.pinners[name])
+	}
+	return pinners
+}
+
+// QueuedStatuses returns the initial pin status for every provider, to be
+// stored with a newly registered plugin or artifact before Enqueue is
+// called. artifact is empty for the plugin's own CID.
+func (q *Queue) QueuedStatuses(artifact string) []*pb.PinStatus {
+	pins := make([]*pb.PinStatus, 0, len(q.order))
+	for _, provider := range q.order {
+		pins = append(pins, &pb.PinStatus{
+			Provider:  provider,
+			State:     pb.PinState_PIN_STATE_QUEUED,
+			UpdatedAt: time.Now().Unix(),
+			Cid:       artifact,
+		})
+	}
+	return pins
+}
+
+// Enqueue persists one job per provider for a registered plugin version or
+// one of its artifacts.
+func (q *Queue) Enqueue(ctx context.Context, name, version, artifact string, c cid.Cid) error {
+	for _, provider := range q.order {
+		job := &store.PinJob{
+			ID:          store.PinJobID(name, version, artifact, provider),
+			Name:        name,
+			Version:     version,
+			CID:         c.String(),
+			Provider:    provider,
+			Artifact:    artifact,
+			NextAttempt: time.Now(),
+		}
+		if err := q.store.PutPinJob(ctx, job); err != nil {
+			return fmt.Errorf("failed to enqueue pin of %s to %s: %w", c, provider, err)
+		}
+	}
+	q.notify()
+	return nil
+}
+
+func (q *Queue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run processes due jobs until ctx is cancelled. Jobs left over from a
+// previous run are picked up immediately.
+func (q *Queue) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		q.processDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-q.wake:
+		}
+	}
+}
+
+// Flush processes the jobs that are due now, for use once Run has returned
+// during shutdown. Jobs still unfinished when ctx is done stay queued for
+// the next run.
+func (q *Queue) Flush(ctx context.Context) {
+	q.processDue(ctx)
+}
+
+func (q *Queue) processDue(ctx context.Context) {
+	jobs, err := q.store.ListPinJobs(ctx)
+	if err != nil {
+		log.Printf("failed to list pin jobs: %v", err)
+		return
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].NextAttempt.Before(jobs[j].NextAttempt) })
+	now := time.Now()
+	due := 0
+	for due < len(jobs) && !jobs[due].NextAttempt.After(now) {
+		due++
+	}
+	parallel(ctx, pinWorkers, jobs[:due], func(job *store.PinJob) {
+		q.process(ctx, job)
+	})
+}
+
+// parallel calls fn for each item, running up to workers calls at once. It
+// stops handing out items once ctx is done and returns when all calls have.
+func parallel[T any](ctx context.Context, workers int, items []T, fn func(T)) {
+	work := make(chan T)
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i < len(items); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range work {
+				fn(item)
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(work)
+	for _, item := range items {
+		select {
+		case work <- item:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (q *Queue) process(ctx context.Context, job *store.PinJob) {
+	pinner, ok := q.pinners[job.Provider]
+	if !ok {
+		// The provider was removed from the configuration since the job was queued.
+		log.Printf("dropping pin job %s: provider %s is not configured", job.ID, job.Provider)
+		q.finish(ctx, job, &pb.PinStatus{Provider: job.Provider, State: pb.PinState_PIN_STATE_FAILED, Error: "provider not configured"})
+		return
+	}
+	c, err := cid.Decode(job.CID)
+	if err != nil {
+		q.finish(ctx, job, &pb.PinStatus{Provider: job.Provider, State: pb.PinState_PIN_STATE_FAILED, Error: err.Error()})
+		return
+	}
+	if !job.AcceptedAt.IsZero() {
+		q.poll(ctx, pinner, job, c)
+		return
+	}
+
+	q.record(ctx, job, &pb.PinStatus{Provider: job.Provider, State: pb.PinState_PIN_STATE_PINNING, Attempts: int32(job.Attempts)})
+
+	pinCtx, cancel := context.WithTimeout(ctx, pinTimeout)
+	state, requestID, err := pinner.Pin(pinCtx, c, job.Name+"@"+job.Version)
+	cancel()
+	job.Attempts++
+	if err == nil {
+		job.RequestID = requestID
+		q.settle(ctx, job, state)
+		return
+	}
+	if ctx.Err() != nil {
+		// Shutting down; leave the job for the next run.
+		return
+	}
+
+	log.Printf("pin job %s attempt %d failed: %v", job.ID, job.Attempts, err)
+	if job.Attempts >= maxAttempts {
+		q.finish(ctx, job, &pb.PinStatus{Provider: job.Provider, State: pb.PinState_PIN_STATE_FAILED, Attempts: int32(job.Attempts), Error: fmt.Sprintf("giving up after %d attempts: %v", job.Attempts, err)})
+		return
+	}
+	job.LastError = err.Error()
+	job.NextAttempt = time.Now().Add(backoff(job.Attempts))
+	if err := q.store.PutPinJob(ctx, job); err != nil {
+		log.Printf("failed to reschedule pin job %s: %v", job.ID, err)
+	}
+	q.record(ctx, job, &pb.PinStatus{Provider: job.Provider, State: pb.PinState_PIN_STATE_QUEUED, Attempts: int32(job.Attempts), Error: job.LastError})
+}
+
+// poll checks the status of a pin the provider has already accepted.
+func (q *Queue) poll(ctx context.Context, pinner Pinner, job *store.PinJob, c cid.Cid) {
+	statusCtx, cancel := context.WithTimeout(ctx, pinTimeout)
+	state, err := pinner.Status(statusCtx, c, job.RequestID)
+	cancel()
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("pin job %s: failed to check status: %v", job.ID, err)
+		job.LastError = err.Error()
+		q.pollLater(ctx, job, nil)
+		return
+	}
+	if state == pb.PinState_PIN_STATE_UNSPECIFIED {
+		// The provider dropped the request, so pin again.
+		job.AcceptedAt = time.Time{}
+		job.Polls = 0
+		job.RequestID = ""
+		job.NextAttempt = time.Now()
+		job.LastError = "provider lost the pin request, re-pinning"
+		if err := q.store.PutPinJob(ctx, job); err != nil {
+			log.Printf("failed to reschedule pin job %s: %v", job.ID, err)
+		}
+		q.record(ctx, job, &pb.PinStatus{Provider: job.Provider, State: pb.PinState_PIN_STATE_QUEUED, Attempts: int32(job.Attempts), Error: job.LastError})
+		return
+	}
+	q.settle(ctx, job, state)
+}
+
+// settle finishes a job whose provider reached a final state, or records its
+// progress and keeps polling.
+func (q *Queue) settle(ctx context.Context, job *store.PinJob, state pb.PinState) {
+	status := &pb.PinStatus{Provider: job.Provider, State: state, Attempts: int32(job.Attempts)}
+	if state == pb.PinState_PIN_STATE_PINNED || state == pb.PinState_PIN_STATE_FAILED {
+		q.finish(ctx, job, status)
+		return
+	}
+	if job.AcceptedAt.IsZero() {
+		job.AcceptedAt = time.Now()
+	}
+	job.LastError = ""
+	q.pollLater(ctx, job, status)
+}
+
+// pollLater schedules the next status check of an accepted job and records
+// status if given, or fails the job once the provider has had maxPinWait.
+func (q *Queue) pollLater(ctx context.Context, job *store.PinJob, status *pb.PinStatus) {
+	if time.Since(job.AcceptedAt) > maxPinWait {
+		log.Printf("pin job %s: provider did not finish within %s", job.ID, maxPinWait)
+		q.finish(ctx, job, &pb.PinStatus{Provider: job.Provider, State: pb.PinState_PIN_STATE_FAILED, Attempts: int32(job.Attempts), Error: fmt.Sprintf("provider did not finish pinning within %s", maxPinWait)})
+		return
+	}
+	job.NextAttempt = time.Now().Add(pollDelay(job.Polls))
+	job.Polls++
+	if err := q.store.PutPinJob(ctx, job); err != nil {
+		log.Printf("failed to reschedule pin job %s: %v", job.ID, err)
+	}
+	if status != nil {
+		q.record(ctx, job, status)
+	}
+}
+
+// finish records the final status of a job and removes it from the queue.
+func (q *Queue) finish(ctx context.Context, job *store.PinJob, status *pb.PinStatus) {
+	q.record(ctx, job, status)
+	if err := q.store.DeletePinJob(ctx, job.ID); err != nil {
+		log.Printf("failed to delete pin job %s: %v", job.ID, err)
+	}
+}
+
+func (q *Queue) record(ctx context.Context, job *store.PinJob, status *pb.PinStatus) {
+	status.Cid = job.Artifact
+	if err := q.update(ctx, job.Name, job.Version, status); err != nil {
+		log.Printf("failed to record pin status for %s: %v", job.ID, err)
+	}
+}
+
+// update stores new pin statuses on a plugin version and recomputes its
+// replication level. A plugin that has since been deleted is ignored.
+func (q *Queue) update(ctx context.Context, name, version string, statuses ...*pb.PinStatus) error {
+	minReplicas, err := q.MinReplicas(ctx, name)
+	if err != nil {
+		return err
+	}
+	_, err = q.store.UpdatePlugin(ctx, name, version, func(p *pb.Plugin) error {
+		for _, status := range statuses {
+			status.UpdatedAt = time.Now().Unix()
+			SetStatus(p, status)
+		}
+		SetReplication(p, minReplicas)
+		return nil
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// ApplyReplication fills in the replication fields of a plugin that is about
+// to be stored.
+func (q *Queue) ApplyReplication(ctx context.Context, p *pb.Plugin) error {
+	minReplicas, err := q.MinReplicas(ctx, p.Name)
+	if err != nil {
+		return err
+	}
+	SetReplication(p, minReplicas)
+	return nil
+}
+
+// MinReplicas returns the replication policy of the plugin's namespace.
+func (q *Queue) MinReplicas(ctx context.Context, name string) (int, error) {
+	nsName, _, _ := strings.Cut(name, "/")
+	ns, err := q.store.GetNamespace(ctx, nsName)
+	if errors.Is(err, store.ErrNotFound) {
+		return q.defaultReplicas, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if ns.MinReplicas != nil {
+		return int(*ns.MinReplicas), nil
+	}
+	return q.defaultReplicas, nil
+}
+
+// SetReplication counts providers that hold p, with all of its artifacts,
+// and marks it available once that reaches the policy.
+func SetReplication(p *pb.Plugin, minReplicas int) {
+	pinned := make(map[string]bool)
+	for _, pin := range p.Pins {
+		held, seen := pinned[pin.Provider]
+		pinned[pin.Provider] = (held || !seen) && pin.State == pb.PinState_PIN_STATE_PINNED
+	}
+	var replicas int32
+	for _, held := range pinned {
+		if held {
+			replicas++
+		}
+	}
+	p.Replicas = replicas
+	p.MinReplicas = int32(minReplicas)
+	p.Available = replicas >= int32(minReplicas)
+}
+
+// SetStatus replaces the status for status.Provider and status.Cid on p,
+// or appends it.
+func SetStatus(p *pb.Plugin, status *pb.PinStatus) {
+	for i, existing := range p.Pins {
+		if existing.Provider == status.Provider && existing.Cid == status.Cid {
+			p.Pins[i] = status
+			return
+		}
+	}
+	p.Pins = append(p.Pins, status)
+}
+
+// pollDelay returns the delay before the status of an accepted pin is checked
+// again, after the given number of checks.
+func pollDelay(polls int) time.Duration {
+	d := basePollDelay << polls
+	if d <= 0 || d > maxPollDelay {
+		return maxPollDelay
+	}
+	return d
+}
+
+// backoff returns the delay before the given attempt is retried.
+func backoff(attempts int) time.Duration {
+	d := baseBackoff << (attempts - 1)
+	if d <= 0 || d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
@@ -0,0 +1,241 @@
+package pinning
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"spacecore_registry/internal/store"
+	"spacecore_registry/pb"
+
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+)
+
+func testCID(t *testing.T, data string) cid.Cid {
+	t.Helper()
+	c, err := cid.Prefix{Version: 1, Codec: cid.Raw, MhType: multihash.SHA2_256, MhLength: -1}.Sum([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func openStore(t *testing.T) *store.BoltStore {
+	t.Helper()
+	b, err := store.NewBoltStore(filepath.Join(t.TempDir(), "registry.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { b.Close() })
+	return b
+}
+
+// fakePinner accepts pins with a request ID and reports the state set for
+// that request.
+type fakePinner struct {
+	name string
+
+	mu       sync.Mutex
+	pinErr   error
+	pinState pb.PinState
+	pins     int
+	// states maps request IDs, or CIDs for lookups without one, to states.
+	states   map[string]pb.PinState
+	statusID []string
+}
+
+func newFakePinner(name string) *fakePinner {
+	return &fakePinner{name: name, pinState: pb.PinState_PIN_STATE_QUEUED, states: make(map[string]pb.PinState)}
+}
+
+func (p *fakePinner) Name() string { return p.name }
+
+func (p *fakePinner) Pin(ctx context.Context, c cid.Cid, name string) (pb.PinState, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pins++
+	if p.pinErr != nil {
+		return pb.PinState_PIN_STATE_FAILED, "", p.pinErr
+	}
+	return p.pinState, "req-" + c.String(), nil
+}
+
+func (p *fakePinner) Status(ctx context.Context, c cid.Cid, requestID string) (pb.PinState, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.statusID = append(p.statusID, requestID)
+	if requestID == "" {
+		return p.states[c.String()], nil
+	}
+	return p.states[requestID], nil
+}
+
+func (p *fakePinner) Ping(ctx context.Context) error { return nil }
+
+func (p *fakePinner) set(key string, state pb.PinState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.states[key] = state
+}
+
+// makeDue moves every queued job's next attempt to now.
+func makeDue(t *testing.T, s store.MetadataStore) {
+	t.Helper()
+	ctx := context.Background()
+	jobs, err := s.ListPinJobs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, job := range jobs {
+		job.NextAttempt = time.Now()
+		if err := s.PutPinJob(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func pinJobs(t *testing.T, s store.MetadataStore) []*store.PinJob {
+	t.Helper()
+	jobs, err := s.ListPinJobs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return jobs
+}
+
+func createQueued(t *testing.T, s store.MetadataStore, q *Queue, c cid.Cid) {
+	t.Helper()
+	ctx := context.Background()
+	plugin := &pb.Plugin{Name: "acme/tool", Version: "1.0.0", Cid: "/ipfs/" + c.String(), Pins: q.QueuedStatuses("")}
+	if err := s.CreatePlugin(ctx, plugin); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Enqueue(ctx, plugin.Name, plugin.Version, "", c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func storedPlugin(t *testing.T, s store.MetadataStore) *pb.Plugin {
+	t.Helper()
+	p, err := s.GetPlugin(context.Background(), "acme/tool", "1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestQueuePollsAcceptedPinByRequestID(t *testing.T) {
+	ctx := context.Background()
+	s := openStore(t)
+	pinner := newFakePinner("svc")
+	q, err := NewQueue(s, []Pinner{pinner}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := testCID(t, "plugin")
+	createQueued(t, s, q, c)
+
+	q.Flush(ctx)
+	jobs := pinJobs(t, s)
+	if len(jobs) != 1 {
+		t.Fatalf("got %d jobs after the pin was accepted, want 1", len(jobs))
+	}
+	if jobs[0].RequestID != "req-"+c.String() || jobs[0].AcceptedAt.IsZero() {
+		t.Errorf("job = %+v, want it accepted with the provider's request ID", jobs[0])
+	}
+	if p := storedPlugin(t, s); p.Pins[0].State != pb.PinState_PIN_STATE_QUEUED || p.Available {
+		t.Errorf("plugin after accept: pins %v, available %v", p.Pins, p.Available)
+	}
+
+	pinner.set("req-"+c.String(), pb.PinState_PIN_STATE_PINNED)
+	makeDue(t, s)
+	q.Flush(ctx)
+	if jobs := pinJobs(t, s); len(jobs) != 0 {
+		t.Errorf("got %d jobs after the pin finished, want 0", len(jobs))
+	}
+	if got := pinner.statusID; len(got) != 1 || got[0] != "req-"+c.String() {
+		t.Errorf("Status called with request IDs %q", got)
+	}
+	p := storedPlugin(t, s)
+	if p.Pins[0].State != pb.PinState_PIN_STATE_PINNED || p.Replicas != 1 || !p.Available {
+		t.Errorf("plugin after pin: pins %v, replicas %d, available %v", p.Pins, p.Replicas, p.Available)
+	}
+}
+
+func TestQueueRepinsLostRequest(t *testing.T) {
+	ctx := context.Background()
+	s := openStore(t)
+	pinner := newFakePinner("svc")
+	q, err := NewQueue(s, []Pinner{pinner}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	createQueued(t, s, q, testCID(t, "plugin"))
+
+	q.Flush(ctx)
+	// The provider has no record of the request, so the job starts over.
+	makeDue(t, s)
+	q.Flush(ctx)
+	jobs := pinJobs(t, s)
+	if len(jobs) != 1 {
+		t.Fatalf("got %d jobs, want 1", len(jobs))
+	}
+	if !jobs[0].AcceptedAt.IsZero() || jobs[0].RequestID != "" {
+		t.Errorf("job = %+v, want it reset for a new pin", jobs[0])
+	}
+	makeDue(t, s)
+	q.Flush(ctx)
+	if pinner.pins != 2 {
+		t.Errorf("Pin called %d times, want 2", pinner.pins)
+	}
+}
+
+func TestQueueFailsAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	s := openStore(t)
+	pinner := newFakePinner("svc")
+	pinner.pinErr = errors.New("service unavailable")
+	q, err := NewQueue(s, []Pinner{pinner}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	createQueued(t, s, q, testCID(t, "plugin"))
+
+	for i := 1; i < maxAttempts; i++ {
+		q.Flush(ctx)
+		jobs := pinJobs(t, s)
+		if len(jobs) != 1 || jobs[0].Attempts != i {
+			t.Fatalf("after attempt %d: jobs %v", i, jobs)
+		}
+		if !jobs[0].NextAttempt.After(time.Now()) {
+			t.Errorf("attempt %d was not backed off", i)
+		}
+		makeDue(t, s)
+	}
+	q.Flush(ctx)
+	if jobs := pinJobs(t, s); len(jobs) != 0 {
+		t.Errorf("got %d jobs after %d attempts, want 0", len(jobs), maxAttempts)
+	}
+	p := storedPlugin(t, s)
+	if pin := p.Pins[0]; pin.State != pb.PinState_PIN_STATE_FAILED || pin.Attempts != maxAttempts || pin.Error != "giving up after 8 attempts: service unavailable" {
+		t.Errorf("pin status = %v", pin)
+	}
+	if p.Available {
+		t.Error("plugin is available without any replicas")
+	}
+}
+
+func TestNewQueueReplication(t *testing.T) {
+	s := openStore(t)
+	pinners := []Pinner{newFakePinner("a"), newFakePinner("b")}
+	if _, err := NewQueue(s, pinners, 3); err == nil {
+		t.Error("NewQueue accepted a replication level above the number of providers")
+	}
+	if _, err := NewQueue(s, pinners, -1); err == nil {
+		t.Error("NewQueue accepted a negative replication level")
+	}
+}
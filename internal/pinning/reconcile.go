@@ -0,0 +1,200 @@
+package pinning
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"spacecore_registry/internal/store"
+	"spacecore_registry/pb"
+
+	"github.com/ipfs/go-cid"
+)
+
+// RunReconciler calls Reconcile every interval until ctx is cancelled.
+func (q *Queue) RunReconciler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			q.Reconcile(ctx)
+		}
+	}
+}
+
+const (
+	// reconcileBatch is the number of plugin versions checked per run.
+	reconcileBatch = 200
+	// reconcileWorkers is the number of plugin versions checked at once.
+	reconcileWorkers = 8
+)
+
+// Reconcile asks every provider whether it still holds the next batch of
+// plugin versions, resuming where the previous run stopped. Content a
+// provider has lost, or never received, is queued to be pinned again until
+// it has failed maxAttempts times, and replication levels are refreshed so
+// policy changes take effect. Reconcile must not run concurrently with
+// itself.
+func (q *Queue) Reconcile(ctx context.Context) {
+	plugins, err := q.store.ListPlugins(ctx)
+	if err != nil {
+		log.Printf("reconcile: failed to list plugins: %v", err)
+		return
+	}
+	jobs, err := q.store.ListPinJobs(ctx)
+	if err != nil {
+		log.Printf("reconcile: failed to list pin jobs: %v", err)
+		return
+	}
+	pending := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		pending[job.ID] = true
+	}
+
+	batch := q.nextBatch(plugins)
+	var requeued atomic.Bool
+	parallel(ctx, reconcileWorkers, batch, func(plugin *pb.Plugin) {
+		if q.reconcile(ctx, plugin, pending) {
+			requeued.Store(true)
+		}
+	})
+	if requeued.Load() {
+		q.notify()
+	}
+}
+
+// nextBatch returns up to reconcileBatch plugin versions following the last
+// one reconciled, starting over once every version has been checked.
+func (q *Queue) nextBatch(plugins []*pb.Plugin) []*pb.Plugin {
+	sort.Slice(plugins, func(i, j int) bool { return pluginKey(plugins[i]) < pluginKey(plugins[j]) })
+	start := sort.Search(len(plugins), func(i int) bool { return pluginKey(plugins[i]) > q.reconciled })
+	batch := plugins[start:min(start+reconcileBatch, len(plugins))]
+	if len(batch) == 0 || start+len(batch) == len(plugins) {
+		q.reconciled = ""
+	} else {
+		q.reconciled = pluginKey(batch[len(batch)-1])
+	}
+	return batch
+}
+
+func pluginKey(p *pb.Plugin) string {
+	return p.Name + "@" + p.Version
+}
+
+// reconcile checks one plugin version on every provider and reports whether
+// it queued any pins.
+func (q *Queue) reconcile(ctx context.Context, plugin *pb.Plugin, pending map[string]bool) bool {
+	requeued := false
+	var changes []*pb.PinStatus
+	for _, target := range pinTargets(plugin) {
+		c, err := cid.Decode(target.cid)
+		if err != nil {
+			log.Printf("reconcile: %s:%s has invalid CID %q: %v", plugin.Name, plugin.Version, target.cid, err)
+			continue
+		}
+		for _, provider := range q.order {
+			if ctx.Err() != nil {
+				return requeued
+			}
+			jobID := store.PinJobID(plugin.Name, plugin.Version, target.artifact, provider)
+			if pending[jobID] {
+				// The queue is already working on it.
+				continue
+			}
+			statusCtx, cancel := context.WithTimeout(ctx, pinTimeout)
+			state, err := q.pinners[provider].Status(statusCtx, c, "")
+			cancel()
+			if err != nil {
+				// Leave the recorded state alone while the provider is unreachable.
+				log.Printf("reconcile: failed to check %s on %s: %v", c, provider, err)
+				continue
+			}
+			recorded := recordedStatus(plugin, provider, target.artifact)
+			switch state {
+			case pb.PinState_PIN_STATE_PINNED, pb.PinState_PIN_STATE_PINNING, pb.PinState_PIN_STATE_QUEUED:
+				if state != recorded.GetState() {
+					changes = append(changes, &pb.PinStatus{Provider: provider, State: state, Cid: target.artifact})
+				}
+			default:
+				// Earlier failures count against the retry limit, but a pin
+				// that has since gone missing starts afresh.
+				var attempts int
+				if recorded.GetState() == pb.PinState_PIN_STATE_PINNED {
+					log.Printf("reconcile: %s lost %s of %s:%s, re-pinning", provider, c, plugin.Name, plugin.Version)
+				} else {
+					attempts = int(recorded.GetAttempts())
+				}
+				if attempts >= maxAttempts {
+					// Give up and keep the failure on record.
+					if recorded.GetState() != pb.PinState_PIN_STATE_FAILED {
+						changes = append(changes, &pb.PinStatus{Provider: provider, State: pb.PinState_PIN_STATE_FAILED, Attempts: int32(attempts), Cid: target.artifact, Error: fmt.Sprintf("giving up after %d attempts", attempts)})
+					}
+					continue
+				}
+				job := &store.PinJob{
+					ID:          jobID,
+					Name:        plugin.Name,
+					Version:     plugin.Version,
+					CID:         c.String(),
+					Provider:    provider,
+					Artifact:    target.artifact,
+					Attempts:    attempts,
+					NextAttempt: time.Now(),
+				}
+				if err := q.store.PutPinJob(ctx, job); err != nil {
+					log.Printf("reconcile: failed to queue %s: %v", jobID, err)
+					continue
+				}
+				requeued = true
+				changes = append(changes, &pb.PinStatus{Provider: provider, State: pb.PinState_PIN_STATE_QUEUED, Attempts: int32(attempts), Cid: target.artifact, Error: "not pinned on provider, re-pinning"})
+			}
+		}
+	}
+	// Always update so replication reflects the current policy.
+	if err := q.update(ctx, plugin.Name, plugin.Version, changes...); err != nil {
+		log.Printf("reconcile: failed to update %s:%s: %v", plugin.Name, plugin.Version, err)
+	}
+	return requeued
+}
+
+type pinTarget struct {
+	// artifact is empty for the plugin's own CID.
+	artifact string
+	cid      string
+}
+
+// pinTargets lists the CIDs of a plugin version that providers should hold:
+// its own and those of any further platform artifacts.
+func pinTargets(p *pb.Plugin) []pinTarget {
+	own := rootCID(p.Cid)
+	targets := []pinTarget{{cid: own}}
+	for _, artifact := range p.Artifacts {
+		if c := rootCID(artifact.Cid); c != own {
+			targets = append(targets, pinTarget{artifact: c, cid: c})
+		}
+	}
+	return targets
+}
+
+// recordedStatus returns the status stored on p for provider and artifact,
+// or nil if there is none.
+func recordedStatus(p *pb.Plugin, provider, artifact string) *pb.PinStatus {
+	for _, pin := range p.Pins {
+		if pin.Provider == provider && pin.Cid == artifact {
+			return pin
+		}
+	}
+	return nil
+}
+
+// rootCID strips the /ipfs/ prefix that plugin records carry on their CID.
+func rootCID(c string) string {
+	return strings.TrimPrefix(c, "/ipfs/")
+}
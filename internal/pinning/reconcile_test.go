@@ -0,0 +1,140 @@
+package pinning
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"spacecore_registry/internal/store"
+	"spacecore_registry/pb"
+)
+
+func createPinned(t *testing.T, s store.MetadataStore, name string, status *pb.PinStatus) *pb.Plugin {
+	t.Helper()
+	plugin := &pb.Plugin{Name: name, Version: "1.0.0", Cid: "/ipfs/" + testCID(t, name).String(), Pins: []*pb.PinStatus{status}}
+	if err := s.CreatePlugin(context.Background(), plugin); err != nil {
+		t.Fatal(err)
+	}
+	return plugin
+}
+
+func TestReconcile(t *testing.T) {
+	tests := []struct {
+		name       string
+		recorded   *pb.PinStatus
+		provider   pb.PinState
+		pending    bool
+		wantJob    bool
+		wantTries  int
+		wantState  pb.PinState
+		wantChecks int
+	}{
+		{
+			name:       "still pinned",
+			recorded:   &pb.PinStatus{Provider: "svc", State: pb.PinState_PIN_STATE_PINNED},
+			provider:   pb.PinState_PIN_STATE_PINNED,
+			wantState:  pb.PinState_PIN_STATE_PINNED,
+			wantChecks: 1,
+		},
+		{
+			name:       "finished since",
+			recorded:   &pb.PinStatus{Provider: "svc", State: pb.PinState_PIN_STATE_QUEUED},
+			provider:   pb.PinState_PIN_STATE_PINNED,
+			wantState:  pb.PinState_PIN_STATE_PINNED,
+			wantChecks: 1,
+		},
+		{
+			name:       "lost pin starts afresh",
+			recorded:   &pb.PinStatus{Provider: "svc", State: pb.PinState_PIN_STATE_PINNED, Attempts: 5},
+			provider:   pb.PinState_PIN_STATE_UNSPECIFIED,
+			wantJob:    true,
+			wantState:  pb.PinState_PIN_STATE_QUEUED,
+			wantChecks: 1,
+		},
+		{
+			name:       "failed pin keeps its attempts",
+			recorded:   &pb.PinStatus{Provider: "svc", State: pb.PinState_PIN_STATE_FAILED, Attempts: 3},
+			provider:   pb.PinState_PIN_STATE_FAILED,
+			wantJob:    true,
+			wantTries:  3,
+			wantState:  pb.PinState_PIN_STATE_QUEUED,
+			wantChecks: 1,
+		},
+		{
+			name:       "gave up",
+			recorded:   &pb.PinStatus{Provider: "svc", State: pb.PinState_PIN_STATE_FAILED, Attempts: maxAttempts},
+			provider:   pb.PinState_PIN_STATE_FAILED,
+			wantState:  pb.PinState_PIN_STATE_FAILED,
+			wantChecks: 1,
+		},
+		{
+			name:      "queue is working on it",
+			recorded:  &pb.PinStatus{Provider: "svc", State: pb.PinState_PIN_STATE_QUEUED},
+			pending:   true,
+			wantJob:   true,
+			wantState: pb.PinState_PIN_STATE_QUEUED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := openStore(t)
+			pinner := newFakePinner("svc")
+			q, err := NewQueue(s, []Pinner{pinner}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			plugin := createPinned(t, s, "acme/tool", tt.recorded)
+			pinner.set(rootCID(plugin.Cid), tt.provider)
+			if tt.pending {
+				job := &store.PinJob{ID: store.PinJobID(plugin.Name, plugin.Version, "", "svc"), Name: plugin.Name, Version: plugin.Version, CID: rootCID(plugin.Cid), Provider: "svc"}
+				if err := s.PutPinJob(ctx, job); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			q.Reconcile(ctx)
+			if got := len(pinner.statusID); got != tt.wantChecks {
+				t.Errorf("Status called %d times, want %d", got, tt.wantChecks)
+			}
+			jobs := pinJobs(t, s)
+			if got := len(jobs) == 1; got != tt.wantJob {
+				t.Fatalf("got %d jobs, want job %v", len(jobs), tt.wantJob)
+			}
+			if tt.wantJob && jobs[0].Attempts != tt.wantTries {
+				t.Errorf("job attempts = %d, want %d", jobs[0].Attempts, tt.wantTries)
+			}
+			stored := storedPlugin(t, s)
+			if got := stored.Pins[0].State; got != tt.wantState {
+				t.Errorf("recorded state = %v, want %v", got, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestReconcileBatches(t *testing.T) {
+	ctx := context.Background()
+	s := openStore(t)
+	pinner := newFakePinner("svc")
+	q, err := NewQueue(s, []Pinner{pinner}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := reconcileBatch + 5
+	for i := 0; i < total; i++ {
+		plugin := createPinned(t, s, fmt.Sprintf("acme/tool-%03d", i), &pb.PinStatus{Provider: "svc", State: pb.PinState_PIN_STATE_PINNED})
+		pinner.set(rootCID(plugin.Cid), pb.PinState_PIN_STATE_PINNED)
+	}
+
+	checked := 0
+	for run, want := range []int{reconcileBatch, 5, reconcileBatch} {
+		q.Reconcile(ctx)
+		if got := len(pinner.statusID) - checked; got != want {
+			t.Errorf("run %d checked %d versions, want %d", run+1, got, want)
+		}
+		checked = len(pinner.statusID)
+	}
+	if jobs := pinJobs(t, s); len(jobs) != 0 {
+		t.Errorf("got %d jobs for pinned content, want 0", len(jobs))
+	}
+}
@@ -0,0 +1,184 @@
+package pinning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"spacecore_registry/pb"
+
+	"github.com/ipfs/go-cid"
+)
+
+// ServicePinner talks to any provider implementing the IPFS Pinning Service
+// API (https://ipfs.github.io/pinning-services-api-spec/).
+type ServicePinner struct {
+	name     string
+	endpoint string
+	token    string
+	origins  []string
+	client   *http.Client
+}
+
+func NewServicePinner(name, endpoint, token string, origins []string) *ServicePinner {
+	return &ServicePinner{
+		name:     name,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		token:    token,
+		origins:  origins,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (p *ServicePinner) Name() string { return p.name }
+
+type servicePin struct {
+	CID     string   `json:"cid"`
+	Name    string   `json:"name,omitempty"`
+	Origins []string `json:"origins,omitempty"`
+}
+
+type servicePinStatus struct {
+	RequestID string `json:"requestid"`
+	Status    string `json:"status"`
+}
+
+func (p *ServicePinner) Pin(ctx context.Context, c cid.Cid, name string) (pb.PinState, string, error) {
+	body, err := json.Marshal(servicePin{CID: c.String(), Name: name, Origins: p.origins})
+	if err != nil {
+		return pb.PinState_PIN_STATE_FAILED, "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/pins", bytes.NewReader(body))
+	if err != nil {
+		return pb.PinState_PIN_STATE_FAILED, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	p.authorize(req)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return pb.PinState_PIN_STATE_FAILED, "", fmt.Errorf("pinning service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return pb.PinState_PIN_STATE_FAILED, "", fmt.Errorf("pinning service returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	var pinStatus servicePinStatus
+	if err := json.NewDecoder(resp.Body).Decode(&pinStatus); err != nil {
+		return pb.PinState_PIN_STATE_FAILED, "", fmt.Errorf("failed to decode pinning service response: %w", err)
+	}
+	return serviceState(pinStatus.Status), pinStatus.RequestID, nil
+}
+
+type servicePinResults struct {
+	Count   int                `json:"count"`
+	Results []servicePinStatus `json:"results"`
+}
+
+// serviceStatuses are the states Status asks for. The API lists only pinned
+// requests unless told otherwise, which would hide pins still in progress.
+const serviceStatuses = "queued,pinning,pinned,failed"
+
+func (p *ServicePinner) Status(ctx context.Context, c cid.Cid, requestID string) (pb.PinState, error) {
+	if requestID != "" {
+		return p.requestStatus(ctx, requestID)
+	}
+	query := url.Values{"cid": {c.String()}, "status": {serviceStatuses}}
+	var results servicePinResults
+	if found, err := p.get(ctx, "/pins?"+query.Encode(), &results); err != nil || !found {
+		return pb.PinState_PIN_STATE_UNSPECIFIED, err
+	}
+	// Several requests may exist for one CID; report the furthest along.
+	best := pb.PinState_PIN_STATE_UNSPECIFIED
+	for _, result := range results.Results {
+		if state := serviceState(result.Status); stateRank(state) > stateRank(best) {
+			best = state
+		}
+	}
+	return best, nil
+}
+
+// requestStatus looks up a single pin request by the ID Pin returned.
+func (p *ServicePinner) requestStatus(ctx context.Context, requestID string) (pb.PinState, error) {
+	var pinStatus servicePinStatus
+	if found, err := p.get(ctx, "/pins/"+url.PathEscape(requestID), &pinStatus); err != nil || !found {
+		return pb.PinState_PIN_STATE_UNSPECIFIED, err
+	}
+	return serviceState(pinStatus.Status), nil
+}
+
+// get decodes the response to a GET of path into v. It reports false
+// without an error if the service returns 404.
+func (p *ServicePinner) get(ctx context.Context, path string, v any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+path, nil)
+	if err != nil {
+		return false, err
+	}
+	p.authorize(req)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("pinning service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return false, fmt.Errorf("pinning service returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return false, fmt.Errorf("failed to decode pinning service response: %w", err)
+	}
+	return true, nil
+}
+
+// Ping lists a single pin, which needs a valid token.
+func (p *ServicePinner) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"/pins?limit=1", nil)
+	if err != nil {
+		return err
+	}
+	p.authorize(req)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("pinning service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("pinning service returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+func (p *ServicePinner) authorize(req *http.Request) {
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+}
+
+// serviceState maps the spec's status values onto PinState.
+func serviceState(status string) pb.PinState {
+	switch status {
+	case "queued":
+		return pb.PinState_PIN_STATE_QUEUED
+	case "pinning":
+		return pb.PinState_PIN_STATE_PINNING
+	case "pinned":
+		return pb.PinState_PIN_STATE_PINNED
+	default:
+		return pb.PinState_PIN_STATE_FAILED
+	}
+}
@@ -0,0 +1,98 @@
+package pinning
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"spacecore_registry/pb"
+)
+
+func TestServicePinnerPin(t *testing.T) {
+	c := testCID(t, "plugin")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/pins" {
+			t.Errorf("got %s %s, want POST /pins", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var pin servicePin
+		if err := json.NewDecoder(r.Body).Decode(&pin); err != nil {
+			t.Fatal(err)
+		}
+		if pin.CID != c.String() || pin.Name != "acme/tool@1.0.0" {
+			t.Errorf("pin = %+v", pin)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(servicePinStatus{RequestID: "req-1", Status: "queued"})
+	}))
+	defer srv.Close()
+
+	p := NewServicePinner("svc", srv.URL+"/", "token", nil)
+	state, requestID, err := p.Pin(context.Background(), c, "acme/tool@1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != pb.PinState_PIN_STATE_QUEUED || requestID != "req-1" {
+		t.Errorf("Pin = %v, %q; want QUEUED, req-1", state, requestID)
+	}
+}
+
+func TestServicePinnerStatus(t *testing.T) {
+	c := testCID(t, "plugin")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pins":
+			if got := r.URL.Query().Get("status"); got != serviceStatuses {
+				t.Errorf("status = %q, want %q", got, serviceStatuses)
+			}
+			if got := r.URL.Query().Get("cid"); got != c.String() {
+				t.Errorf("cid = %q", got)
+			}
+			json.NewEncoder(w).Encode(servicePinResults{Count: 2, Results: []servicePinStatus{
+				{RequestID: "req-1", Status: "failed"},
+				{RequestID: "req-2", Status: "pinning"},
+			}})
+		case "/pins/req-1":
+			json.NewEncoder(w).Encode(servicePinStatus{RequestID: "req-1", Status: "queued"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	p := NewServicePinner("svc", srv.URL, "", nil)
+	tests := []struct {
+		requestID string
+		want      pb.PinState
+	}{
+		{"", pb.PinState_PIN_STATE_PINNING},
+		{"req-1", pb.PinState_PIN_STATE_QUEUED},
+		{"req-gone", pb.PinState_PIN_STATE_UNSPECIFIED},
+	}
+	for _, tt := range tests {
+		got, err := p.Status(context.Background(), c, tt.requestID)
+		if err != nil {
+			t.Errorf("Status(%q): %v", tt.requestID, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Status(%q) = %v, want %v", tt.requestID, got, tt.want)
+		}
+	}
+}
+
+func TestServicePinnerStatusError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad token", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	p := NewServicePinner("svc", srv.URL, "", nil)
+	if _, err := p.Status(context.Background(), testCID(t, "plugin"), ""); err == nil {
+		t.Error("Status succeeded against a failing service")
+	}
+}
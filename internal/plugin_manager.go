@@ -2,256 +2,735 @@ package internal
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"os"
+	"spacecore_registry/internal/config"
+	"spacecore_registry/internal/content"
+	"spacecore_registry/internal/index"
+	"spacecore_registry/internal/metrics"
+	"spacecore_registry/internal/p2p"
+	"spacecore_registry/internal/pinning"
+	"spacecore_registry/internal/search"
+	"spacecore_registry/internal/store"
 	"spacecore_registry/pb"
-	"strings"
 
-	"github.com/ipfs/boxo/files"
 	"github.com/ipfs/boxo/path"
-	"github.com/ipfs/kubo/client/rpc"
-	"github.com/joho/godotenv"
-	"github.com/redis/go-redis/v9"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	"github.com/libp2p/go-libp2p/core/host"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	// "github.com/libp2p/go-libp2p/p2p/discovery/routing"
 )
 
 type pluginRegistryServer struct {
 	pb.UnimplementedPluginRegistryServer
-	ipfsClient  *rpc.HttpApi
-	redisClient *redis.Client
-	// dht *kbucket.RoutingTable
-	pinataAPIKey    string
-	pinataAPISecret string
+	content  content.Backend
+	store    store.MetadataStore
+	dht      *dht.IpfsDHT
+	pinQueue *pinning.Queue
+	// federation holds plugin indexes fetched from other registries.
+	federation *p2p.Federation
+	announcer  *p2p.Announcer
+	index      *index.Publisher
+	search     *search.Index
+	// maxUploadSize caps the bytes read for one plugin binary; zero means
+	// no limit.
+	maxUploadSize int64
 }
 
-// Define a struct for the Pinata pin request body
-type pinataPinRequest struct {
-	HashToPin string `json:"hashToPin"`
+// NewPluginRegistryServer opens the content backend, metadata store and
+// pinning providers described by cfg and wires them to the libp2p host. The
+// background loops are started by Start, and Close releases what was opened.
+func NewPluginRegistryServer(ctx context.Context, cfg *config.Config, h host.Host, kadDHT *dht.IpfsDHT) (server *pluginRegistryServer, err error) {
+	// Everything acquired is released again, in reverse, if a later step
+	// fails.
+	var cleanup []func() error
+	defer func() {
+		if err != nil {
+			for i := len(cleanup) - 1; i >= 0; i-- {
+				cleanup[i]()
+			}
+		}
+	}()
+
+	backend, err := content.Open(ctx, content.Options{
+		Backend:  cfg.IPFS.Backend,
+		APIAddr:  cfg.IPFS.API,
+		Host:     h,
+		Routing:  kadDHT,
+		RepoPath: cfg.IPFS.RepoPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open IPFS backend: %w", err)
+	}
+	cleanup = append(cleanup, backend.Close)
+	metadataStore, err := store.Open(store.Options{
+		Backend:   cfg.Store.Backend,
+		RedisAddr: cfg.Store.RedisAddr,
+		BoltPath:  cfg.Store.BoltPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open metadata store: %w", err)
+	}
+	cleanup = append(cleanup, metadataStore.Close)
+	pinners, err := pinning.Open(pinningOptions(cfg.Pinning, backend))
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure pinning: %w", err)
+	}
+	pinQueue, err := pinning.NewQueue(metadataStore, pinners, cfg.DefaultMinReplicas(len(pinners)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure pinning: %w", err)
+	}
+	federation := p2p.NewFederation(h, kadDHT, federationIndex(metadataStore), verifyPluginRecord)
+	cleanup = append(cleanup, func() error {
+		federation.Close()
+		return nil
+	})
+	announcer, err := p2p.NewAnnouncer(ctx, h, kadDHT, verifyPluginRecord)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join announcement topic: %w", err)
+	}
+	cleanup = append(cleanup, announcer.Close)
+	searchIndex, err := search.Build(ctx, metadataStore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build search index: %w", err)
+	}
+	return &pluginRegistryServer{
+		content:    backend,
+		store:      metadataStore,
+		dht:        kadDHT,
+		pinQueue:   pinQueue,
+		federation: federation,
+		announcer:  announcer,
+		index:      index.NewPublisher(metadataStore, backend, kadDHT, h.Peerstore().PrivKey(h.ID())),
+		search:     searchIndex,
+
+		maxUploadSize: cfg.GRPC.MaxUploadSize,
+	}, nil
 }
 
-func NewPluginRegistryServer(ipfsClient *rpc.HttpApi, redisClient *redis.Client, pinataAPIKey string, pinataAPISecret string) *pluginRegistryServer {
-	return &pluginRegistryServer{
-		ipfsClient:      ipfsClient,
-		redisClient:     redisClient,
-		pinataAPIKey:    pinataAPIKey,
-		pinataAPISecret: pinataAPISecret,
-	}
+// Close leaves the announcement topic, stops serving the federation index
+// and closes the metadata store and content backend.
+func (s *pluginRegistryServer) Close() error {
+	s.federation.Close()
+	return errors.Join(s.announcer.Close(), s.store.Close(), s.content.Close())
 }
 
 // Example: vimana register ipfst /Users/mayurchougule/development/spacecore-plugins/ipfs-plugin/bin/ipfspd
+//
+// RegisterPlugin reads req.Plugin from the registry host's filesystem, so it
+// only works for publishers on the same machine. Remote publishers use
+// UploadPlugin instead.
 func (s *pluginRegistryServer) RegisterPlugin(ctx context.Context, req *pb.RegisterPluginRequest) (*pb.RegisterPluginResponse, error) {
-	log.Printf("req plugin: %v\n", req.Plugin)
-	// cid, err := s.ipfsClient.Unixfs().Add(ctx, fileNode)
+	if err := validatePluginName(req.Name); err != nil {
+		return nil, err
+	}
+	version, err := normalizeVersion(req.Version)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := normalizedManifest(req.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDependencies(ctx, req.Name, manifest); err != nil {
+		return nil, err
+	}
+	platforms, err := normalizePlatforms(req.Platforms)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPublisherKey(ctx, req.Name, req.PublicKey, req.Signature); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(req.Plugin)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot read plugin file %q", req.Plugin)
+	}
+	defer f.Close()
+	cid, digest, size, err := s.addContent(ctx, f)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to add plugin to IPFS: %w", err)
+	}
 
-	cid, err := s.ipfsClient.Unixfs().Add(ctx, getUnixfsNode(req.Plugin))
-	cidPath, err := path.NewPath(req.Plugin)
-	log.Printf("cid: %v\n", cid)
-	log.Printf("cidPath: %v\n", cidPath)
+	return s.publishPlugin(ctx, req.Name, version, manifest, req.Manifest, cid, &pb.Artifact{
+		Platforms: platforms,
+		Signature: req.Signature,
+		PublicKey: req.PublicKey,
+		Sha256:    digest,
+		Size:      size,
+	})
+}
 
-	// Assuming `cid` is the CID of the content you want to pin
+// UploadPlugin receives a header frame followed by the plugin binary in
+// chunks. Chunks are streamed into IPFS as they arrive rather than buffered.
+func (s *pluginRegistryServer) UploadPlugin(stream pb.PluginRegistry_UploadPluginServer) error {
+	ctx := stream.Context()
 
-	err = s.ipfsClient.Pin().Add(ctx, cid)
+	first, err := stream.Recv()
 	if err != nil {
-		return nil, fmt.Errorf("failed to pin content locally: %w", err)
+		return err
+	}
+	header := first.GetHeader()
+	if header == nil {
+		return status.Error(codes.InvalidArgument, "first upload frame must be a header")
+	}
+	if header.Name == "" || header.Version == "" {
+		return status.Error(codes.InvalidArgument, "upload header requires name and version")
+	}
+	if err := validatePluginName(header.Name); err != nil {
+		return err
+	}
+	version, err := normalizeVersion(header.Version)
+	if err != nil {
+		return err
+	}
+	manifest, err := normalizedManifest(header.Manifest)
+	if err != nil {
+		return err
+	}
+	if err := s.checkDependencies(ctx, header.Name, manifest); err != nil {
+		return err
+	}
+	platforms, err := normalizePlatforms(header.Platforms)
+	if err != nil {
+		return err
+	}
+	if err := s.checkPublisherKey(ctx, header.Name, header.PublicKey, header.Signature); err != nil {
+		return err
 	}
-	log.Printf("Successfully pinned CID: %s locally\n", cid)
 
-	gatewayUrl, _ := pinToPinata(cid.String())
+	pr, pw := io.Pipe()
+	received := make(chan struct{})
+	go func() {
+		defer close(received)
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				pw.Close()
+				return
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			chunk := req.GetChunk()
+			if chunk == nil {
+				pw.CloseWithError(status.Error(codes.InvalidArgument, "header may only be sent once"))
+				return
+			}
+			if _, err := pw.Write(chunk); err != nil {
+				// The reader side has gone away; Add has already failed.
+				return
+			}
+		}
+	}()
+
+	cid, digest, size, err := s.addContent(ctx, pr)
+	if err != nil {
+		// Unblock the receiver; it exits once the stream is torn down.
+		pr.CloseWithError(err)
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return fmt.Errorf("failed to add plugin to IPFS: %w", err)
+	}
+	// Add read the pipe to EOF, so the receiver has finished.
+	<-received
+	if size == 0 {
+		return status.Error(codes.InvalidArgument, "upload contained no plugin data")
+	}
+	log.Printf("Uploaded %d bytes for %s:%s, cid: %v\n", size, header.Name, version, cid)
+
+	resp, err := s.publishPlugin(ctx, header.Name, version, manifest, header.Manifest, cid, &pb.Artifact{
+		Platforms: platforms,
+		Signature: header.Signature,
+		PublicKey: header.PublicKey,
+		Sha256:    digest,
+		Size:      size,
+	})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(resp)
+}
 
+// publishPlugin registers the binary added at cid, described by artifact, as
+// name at version, or as another platform build of it. manifest is the
+// normalized form of sent, the manifest the publisher signed. It verifies
+// the publisher signature and namespace permissions, then pins the content
+// and records it in the metadata store.
+func (s *pluginRegistryServer) publishPlugin(ctx context.Context, name, version string, manifest, sent *pb.PluginManifest, cid path.ImmutablePath, artifact *pb.Artifact) (*pb.RegisterPluginResponse, error) {
+	manifestSum, err := manifestDigest(sent)
+	if err != nil {
+		return nil, err
+	}
+	payload := pluginSignaturePayload(cid.RootCid().String(), name, version, artifact.Platforms, manifestSum)
+	publisher, err := verifySignature(artifact.PublicKey, artifact.Signature, payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizePublisher(ctx, name, publisher); err != nil {
+		return nil, err
+	}
+	artifact.Cid = cid.String()
+	artifact.Publisher = publisher.String()
 	plugin := &pb.Plugin{
-		Name:    req.Name,
-		Version: req.Version,
-		Cid:     cid.String(),
+		Name:      name,
+		Version:   version,
+		Manifest:  manifest,
+		Platforms: artifact.Platforms,
+		Artifacts: []*pb.Artifact{artifact},
 	}
+	if !proto.Equal(manifest, sent) {
+		plugin.SignedManifest = sent
+	}
+	useArtifact(plugin, artifact)
+	log.Printf("Verified signature on %s:%s from %s\n", name, version, publisher)
 
-	// Use the CID as the key in the DHT
-	// // Provide the CID in the DHT - cannot use putvalue because its intended use is a bit different
-	// // https://github.com/libp2p/go-libp2p-kad-dht/issues/381
-	// if err := s.dht.Provide(ctx, cid.RootCid(), true); err != nil {
-	// 	return nil, fmt.Errorf("failed to provide CID: %w", err)
-	// }
+	// A legacy record stored under another spelling of version, such as
+	// "1.0", is the same release and stays immutable.
+	if existing, err := s.resolvePlugin(ctx, name, version); err == nil && existing.Version != version {
+		return nil, status.Errorf(codes.AlreadyExists, "version %s of %s is already registered as %s", version, name, existing.Version)
+	}
 
-	key := fmt.Sprintf("plugin:%s:%s", req.Name, req.Version)
-	value, err := json.Marshal(plugin)
-	if err != nil {
+	// Fail fast before pinning; CreatePlugin re-checks atomically below.
+	if existing, err := s.store.GetPlugin(ctx, name, version); err == nil {
+		return s.addArtifact(ctx, existing, manifestSum, artifact, cid)
+	} else if !errors.Is(err, store.ErrNotFound) {
 		return nil, err
 	}
 
-	if err := s.redisClient.Set(ctx, key, value, 0).Err(); err != nil {
+	err = s.content.Pin(ctx, cid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pin content locally: %w", err)
+	}
+	log.Printf("Successfully pinned CID: %s locally\n", cid)
+
+	plugin.Pins = s.pinQueue.QueuedStatuses("")
+	if err := s.pinQueue.ApplyReplication(ctx, plugin); err != nil {
 		return nil, err
 	}
 
-	log.Printf("Registered Plugin: %s with CID: %s", req.Name, gatewayUrl)
+	// Announce the CID as a provider record. Failure isn't fatal: the
+	// reprovider announces it again on its next pass.
+	if err := s.content.Provide(ctx, cid); err != nil {
+		log.Printf("failed to provide %s: %v", cid, err)
+	}
+
+	if err := s.store.CreatePlugin(ctx, plugin); err != nil {
+		if !errors.Is(err, store.ErrExists) {
+			return nil, err
+		}
+		existing, err := s.store.GetPlugin(ctx, name, version)
+		if err != nil {
+			return nil, err
+		}
+		return s.addArtifact(ctx, existing, manifestSum, artifact, cid)
+	}
+
+	s.enqueuePins(ctx, plugin, "", cid)
+	log.Printf("Registered Plugin: %s with CID: %s", name, cid)
+	s.published(ctx, plugin)
 	return &pb.RegisterPluginResponse{
-		Message: "Plugin registered successfully",
-		Cid:     gatewayUrl,
+		Message:     "Plugin registered successfully",
+		Cid:         plugin.Cid,
+		Pins:        plugin.Pins,
+		Replicas:    plugin.Replicas,
+		MinReplicas: plugin.MinReplicas,
+		Available:   plugin.Available,
+		Sha256:      artifact.Sha256,
+		Size:        artifact.Size,
 	}, nil
-
 }
 
-func (s *pluginRegistryServer) DiscoverPlugins(ctx context.Context, req *pb.DiscoverPluginsRequest) (*pb.DiscoverPluginsResponse, error) {
-	plugins := []*pb.Plugin{}
+// addArtifact registers another platform build of an existing version.
+// Registering a CID the version already has succeeds without changes.
+// manifest is the manifestDigest the build was signed with, which must be
+// that of the version.
+func (s *pluginRegistryServer) addArtifact(ctx context.Context, existing *pb.Plugin, manifest string, artifact *pb.Artifact, cid path.ImmutablePath) (*pb.RegisterPluginResponse, error) {
+	root := cid.RootCid().String()
+	if registered := findArtifact(existing, root); registered != nil {
+		return alreadyRegistered(existing, registered), nil
+	}
+	existingManifest, err := manifestDigest(signedManifest(existing))
+	if err != nil {
+		return nil, err
+	}
+	if manifest != existingManifest {
+		return nil, status.Errorf(codes.FailedPrecondition, "manifest differs from the one registered for %s:%s; every build of a version must carry the same manifest", existing.Name, existing.Version)
+	}
+	if existing.Yanked {
+		return nil, yankedError(existing)
+	}
+	if err := artifactConflict(existing, artifact); err != nil {
+		return nil, err
+	}
+	minReplicas, err := s.pinQueue.MinReplicas(ctx, existing.Name)
+	if err != nil {
+		return nil, err
+	}
 
-	// if req.Name is empty, then list top 10 plugins
-	if *req.Name == "" {
-		searchKey := "plugin:*"
-		// Use the Keys method to find all keys matching the pattern
-		keys, err := s.redisClient.Keys(ctx, searchKey).Result()
-		if err != nil {
-			return nil, err
+	if err := s.content.Pin(ctx, cid); err != nil {
+		return nil, fmt.Errorf("failed to pin content locally: %w", err)
+	}
+	if err := s.content.Provide(ctx, cid); err != nil {
+		log.Printf("failed to provide %s: %v", cid, err)
+	}
+
+	plugin, err := s.store.UpdatePlugin(ctx, existing.Name, existing.Version, func(p *pb.Plugin) error {
+		if findArtifact(p, root) != nil {
+			// Added concurrently by the same publish.
+			return nil
 		}
-		// If no specific search, optionally limit to top 10 keys
-		if *req.Name == "" && len(keys) > 10 {
-			keys = keys[:10]
+		if p.Yanked {
+			return yankedError(p)
 		}
-		for _, key := range keys {
-			value, err := s.redisClient.Get(ctx, key).Result()
-			if err != nil {
-				// Handle error (e.g., key might have expired between fetching keys and values)
-				fmt.Printf("Error fetching value for key %s: %v\n", key, err)
-				continue
-			}
+		if err := artifactConflict(p, artifact); err != nil {
+			return err
+		}
+		p.Artifacts = append(artifactsOf(p), artifact)
+		p.Platforms = mergePlatforms(p.Platforms, artifact.Platforms)
+		for _, pin := range s.pinQueue.QueuedStatuses(root) {
+			pinning.SetStatus(p, pin)
+		}
+		pinning.SetReplication(p, minReplicas)
+		return nil
+	})
+	if err != nil {
+		return nil, storeError(err)
+	}
+	if err := s.store.IndexCID(ctx, root, plugin.Name, plugin.Version); err != nil {
+		log.Printf("failed to index CID %s of %s:%s: %v", root, plugin.Name, plugin.Version, err)
+	}
 
-			var plugin pb.Plugin
-			if err := json.Unmarshal([]byte(value), &plugin); err != nil {
-				log.Printf("failed to unmarshal plugin: %v", err)
-				return nil, err
+	s.enqueuePins(ctx, plugin, root, cid)
+	log.Printf("Registered %s build of %s:%s with CID: %s", platformList(artifact.Platforms), plugin.Name, plugin.Version, cid)
+	s.published(ctx, plugin)
+	return &pb.RegisterPluginResponse{
+		Message:     "Platform build registered successfully",
+		Cid:         artifact.Cid,
+		Pins:        plugin.Pins,
+		Replicas:    plugin.Replicas,
+		MinReplicas: plugin.MinReplicas,
+		Available:   plugin.Available,
+		Sha256:      artifact.Sha256,
+		Size:        artifact.Size,
+	}, nil
+}
+
+// enqueuePins queues remote pins of one of plugin's CIDs. artifact is empty
+// for the plugin's own CID.
+func (s *pluginRegistryServer) enqueuePins(ctx context.Context, plugin *pb.Plugin, artifact string, cid path.ImmutablePath) {
+	enqueueErr := s.pinQueue.Enqueue(ctx, plugin.Name, plugin.Version, artifact, cid.RootCid())
+	if enqueueErr == nil {
+		return
+	}
+	// The plugin is registered and pinned locally; surface the problem in
+	// its pin status instead of failing the whole request.
+	log.Printf("failed to queue remote pins for %s:%s: %v", plugin.Name, plugin.Version, enqueueErr)
+	_, err := s.store.UpdatePlugin(ctx, plugin.Name, plugin.Version, func(p *pb.Plugin) error {
+		for _, pin := range p.Pins {
+			if pin.Cid == artifact {
+				pin.State = pb.PinState_PIN_STATE_FAILED
+				pin.Error = enqueueErr.Error()
 			}
-			plugins = append(plugins, &plugin)
 		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("failed to record pin status for %s:%s: %v", plugin.Name, plugin.Version, err)
+	}
+}
+
+// published tells peers, the IPNS index and search about a new or changed
+// plugin version.
+func (s *pluginRegistryServer) published(ctx context.Context, plugin *pb.Plugin) {
+	s.announce(ctx, pb.PluginAnnouncement_KIND_REGISTERED, plugin)
+	s.index.Changed(plugin.Name)
+	s.search.Put(plugin)
+}
 
-		// can return the list of keys since we are not searching for a specific plugin
-		return &pb.DiscoverPluginsResponse{
-			Plugins: plugins,
-		}, nil
+func yankedError(p *pb.Plugin) error {
+	return status.Errorf(codes.FailedPrecondition, "%s:%s is yanked and accepts no new builds; publish a new version instead", p.Name, p.Version)
+}
+
+func alreadyRegistered(existing *pb.Plugin, artifact *pb.Artifact) *pb.RegisterPluginResponse {
+	return &pb.RegisterPluginResponse{
+		Message:     "Plugin already registered",
+		Cid:         artifact.Cid,
+		Pins:        existing.Pins,
+		Replicas:    existing.Replicas,
+		MinReplicas: existing.MinReplicas,
+		Available:   existing.Available,
+		Sha256:      artifact.Sha256,
+		Size:        artifact.Size,
 	}
+}
 
-	key := fmt.Sprintf("plugin:%s:%s", *req.Name, "1.0")
+func (s *pluginRegistryServer) DiscoverPlugins(ctx context.Context, req *pb.DiscoverPluginsRequest) (*pb.DiscoverPluginsResponse, error) {
+	// if req.Name is empty, then list plugins a page at a time
+	if req.GetName() == "" {
+		var federated []*pb.Plugin
+		if req.IncludeFederated && s.federation != nil {
+			federated = s.federation.Plugins()
+		}
+		return s.listPlugins(ctx, req.PageSize, req.PageToken, federated)
+	}
 
-	value, err := s.redisClient.Get(ctx, key).Result()
-	if err != nil {
+	var plugins []*pb.Plugin
+	plugin, err := s.resolvePlugin(ctx, req.GetName(), req.GetVersion())
+	if err == nil {
+		plugins = append(plugins, plugin)
+	} else if !req.IncludeFederated || status.Code(err) != codes.NotFound {
 		return nil, err
 	}
-
-	log.Printf("value: %v\n", value)
-	var plugin pb.Plugin
-	if err := json.Unmarshal([]byte(value), &plugin); err != nil {
-		log.Printf("failed to unmarshal plugin: %v", err)
+	if req.IncludeFederated {
+		federated, ferr := s.federatedPlugins(req.GetName(), req.GetVersion())
+		if ferr != nil {
+			return nil, ferr
+		}
+		plugins = append(plugins, federated...)
+	}
+	if len(plugins) == 0 {
+		// Nothing here or on any federated registry.
 		return nil, err
 	}
-	// providers := s.dht.FindProvidersAsync(ctx, cid, 5)
-	// responses, err := s.dht.SearchValue(ctx, *req.Name)
-	// value, err := s.dht.GetValue(ctx, *req.Name)
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to get value: %w", err)
-	}
 	return &pb.DiscoverPluginsResponse{
-		Plugins: []*pb.Plugin{&plugin},
+		Plugins: plugins,
 	}, nil
 }
 
-func getUnixfsNode(path string) files.Node {
-	st, err := os.Stat(path)
+// downloadChunkSize keeps each DownloadPlugin frame well under the default
+// 4 MiB gRPC message limit.
+const downloadChunkSize = 256 << 10
+
+// DownloadPlugin streams the plugin identified by req.Cid, or the artifact of
+// req.Name matching req.Os and req.Arch, in chunks, starting
+// at req.Offset so interrupted downloads can resume. The first frame carries
+// the total size and CID.
+func (s *pluginRegistryServer) DownloadPlugin(req *pb.DownloadPluginRequest, stream pb.PluginRegistry_DownloadPluginServer) error {
+	ctx := stream.Context()
+	if req.Offset < 0 {
+		return status.Error(codes.InvalidArgument, "offset must not be negative")
+	}
+	target := req.Cid
+	if target == "" {
+		if req.Name == "" {
+			return status.Error(codes.InvalidArgument, "either cid or name is required")
+		}
+		plugin, err := s.resolvePlugin(ctx, req.Name, req.Version)
+		if err != nil {
+			return err
+		}
+		artifact, err := selectArtifact(plugin, req.Os, req.Arch)
+		if err != nil {
+			return err
+		}
+		target = artifact.Cid
+	}
+	pluginPath, err := path.NewPath(target)
 	if err != nil {
-		return nil
+		return status.Errorf(codes.InvalidArgument, "invalid cid %q: %v", target, err)
 	}
-
-	f, err := files.NewSerialFile(path, false, st)
+	immutablePath, err := path.NewImmutablePath(pluginPath)
 	if err != nil {
-		return nil
+		return status.Errorf(codes.InvalidArgument, "invalid cid %q: %v", target, err)
 	}
-	return f
-}
-
-func (s *pluginRegistryServer) DownloadPlugin(ctx context.Context, req *pb.DownloadPluginRequest) (*pb.DownloadPluginResponse, error) {
-	pluginPath, err := path.NewPath(req.Cid)
+	fileReader, err := s.content.Get(ctx, immutablePath)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res, err := s.ipfsClient.Unixfs().Get(ctx, pluginPath)
+	defer fileReader.Close()
+
+	size, err := fileReader.Size()
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if req.Offset > size {
+		return status.Errorf(codes.OutOfRange, "offset %d is beyond plugin size %d", req.Offset, size)
 	}
 
-	fileReader, ok := res.(files.File)
-	if !ok {
-		return nil, fmt.Errorf("failed to convert fileNode type %T to files.File", res)
+	first := &pb.DownloadPluginResponse{
+		Cid:       target,
+		TotalSize: size,
+		Offset:    req.Offset,
+	}
+	// Hand back the publisher signature so hosts can verify before executing.
+	// Content that was never registered is still served, just unsigned.
+	var plugin *pb.Plugin
+	var artifact *pb.Artifact
+	if immutable, err := path.NewImmutablePath(pluginPath); err == nil {
+		root := immutable.RootCid().String()
+		plugin, err = s.store.GetPluginByCID(ctx, root)
+		switch {
+		case err == nil:
+			if artifact = findArtifact(plugin, root); artifact != nil {
+				first.Publisher = artifact.Publisher
+				first.Signature = artifact.Signature
+				first.PublicKey = artifact.PublicKey
+			}
+		case !errors.Is(err, store.ErrNotFound):
+			return err
+		}
 	}
 
-	data, err := io.ReadAll(fileReader)
-	if err != nil {
-		return nil, err
+	// Check the content against the digest recorded at register time. A
+	// resumed download hashes the skipped prefix as well, so the whole
+	// binary is verified either way.
+	var digest *digester
+	if artifact != nil && artifact.Sha256 != "" {
+		if size != artifact.Size {
+			err := status.Errorf(codes.DataLoss, "content of %s is %d bytes, expected %d", target, size, artifact.Size)
+			log.Printf("refusing to serve %s: %v", target, err)
+			return err
+		}
+		first.Sha256 = artifact.Sha256
+		digest = newDigester()
+		if _, err := io.CopyN(digest, fileReader, req.Offset); err != nil {
+			return fmt.Errorf("failed to read up to offset %d: %w", req.Offset, err)
+		}
+	} else if req.Offset > 0 {
+		if _, err := fileReader.Seek(req.Offset, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to seek to offset %d: %w", req.Offset, err)
+		}
 	}
 
-	return &pb.DownloadPluginResponse{
-		Content: data,
-	}, nil
+	// Each chunk is held back until the next one has been read and hashed,
+	// so a client never receives the end of a binary that failed to verify.
+	var pending *pb.DownloadPluginResponse
+	offset := req.Offset
+	bufs := [2][]byte{make([]byte, downloadChunkSize), make([]byte, downloadChunkSize)}
+	for i := 0; ; i = 1 - i {
+		n, err := io.ReadFull(fileReader, bufs[i])
+		if n > 0 {
+			if digest != nil {
+				digest.Write(bufs[i][:n])
+			}
+			if pending != nil {
+				if err := stream.Send(pending); err != nil {
+					return err
+				}
+				metrics.DownloadedBytes.Add(float64(len(pending.Content)))
+			}
+			frame := &pb.DownloadPluginResponse{Offset: offset}
+			if offset == req.Offset {
+				frame = first
+			}
+			frame.Content = bufs[i][:n]
+			pending = frame
+			offset += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if digest != nil {
+		if err := verifyDigest(digest, artifact.Sha256, artifact.Size); err != nil {
+			log.Printf("refusing to serve %s: %v", target, err)
+			return err
+		}
+	}
+	// Always send the first frame, even when resuming at the very end.
+	if pending == nil {
+		pending = first
+	}
+	if err := stream.Send(pending); err != nil {
+		return err
+	}
+	metrics.DownloadedBytes.Add(float64(len(pending.Content)))
+	if plugin != nil {
+		s.countDownload(ctx, plugin)
+	}
+	return nil
 }
 
 func (s *pluginRegistryServer) GetPlugin(ctx context.Context, req *pb.GetPluginRequest) (*pb.GetPluginResponse, error) {
-	key := fmt.Sprintf("plugin:%s:%s", req.Name, req.Version)
-	value, err := s.redisClient.Get(ctx, key).Result()
+	plugin, err := s.resolvePlugin(ctx, req.Name, req.Version)
 	if err != nil {
 		return &pb.GetPluginResponse{}, err
 	}
-
-	var plugin pb.Plugin
-	if err := json.Unmarshal([]byte(value), &plugin); err != nil {
-		return &pb.GetPluginResponse{}, err
+	if req.Os != "" || req.Arch != "" {
+		artifact, err := selectArtifact(plugin, req.Os, req.Arch)
+		if err != nil {
+			return &pb.GetPluginResponse{}, err
+		}
+		useArtifact(plugin, artifact)
 	}
 
 	return &pb.GetPluginResponse{
-		Plugin: &plugin,
+		Plugin: plugin,
 	}, nil
 }
 
-func pinToPinata(hashToPin string) (string, error) {
-	err := godotenv.Load()
-	// filter out /ipfs/ from the hash
-
-	cidHash := strings.Replace(hashToPin, "/ipfs/", "", 1)
-
-	url := "https://api.pinata.cloud/pinning/pinByHash"
-	body := map[string]interface{}{
-		"hashToPin": cidHash,
-		"pinataOptions": map[string]interface{}{
-			"hostNodes": []string{
-				"/ip4/73.227.56.113/tcp/18667/p2p/12D3KooWJU4TpmDokPbTftD1PsSfuyx8jvysHpS5o9fL224xYKZM",
-			},
-		},
+// YankPlugin hides a published version from latest and range resolution.
+// The version stays retrievable by exact version and downloadable by CID.
+func (s *pluginRegistryServer) YankPlugin(ctx context.Context, req *pb.YankPluginRequest) (*pb.GetPluginResponse, error) {
+	version, err := normalizeVersion(req.Version)
+	if err != nil {
+		return nil, err
 	}
-
-	jsonBody, err := json.Marshal(body)
+	target := req.Name + ":" + version
+	signer, err := verifyRequestSignature(req.Auth, actionYank, namespaceOf(req.Name), target)
 	if err != nil {
-		return "Error parsing body " + hashToPin, err
+		return nil, err
 	}
-	payload := strings.NewReader(string(jsonBody))
-
-	req, err := http.NewRequest("POST", url, payload)
+	// Resolve the stored version, which may be a legacy spelling of version,
+	// before anything is written: a yank of a missing version must not use
+	// up the request sequence.
+	existing, err := s.resolvePlugin(ctx, req.Name, version)
 	if err != nil {
-		return "Error pinning file to Pinata " + hashToPin, err
+		return nil, err
 	}
-
-	gatewayUrl := "https://gateway.pinata.cloud/ipfs/"
-	bearerToken := os.Getenv("JWT")
-	req.Header.Set("Authorization", "Bearer "+bearerToken)
-	req.Header.Set("Content-Type", "application/json")
-	// fmt.Printf("bearer : %v\n", bearerToken)
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	plugin, err := s.store.UpdateNamespacePlugin(ctx, namespaceOf(req.Name), req.Name, existing.Version, func(ns *pb.Namespace, p *pb.Plugin) error {
+		if !canPublish(ns, signer) {
+			return status.Errorf(codes.PermissionDenied, "%s may not yank in namespace %s", signer, ns.Name)
+		}
+		p.Yanked = true
+		p.YankReason = req.Reason
+		return useSequence(ns, req.Auth)
+	})
 	if err != nil {
-		return fmt.Sprintf("failed to pin: %s", hashToPin), err
+		return nil, storeError(err)
 	}
-	defer resp.Body.Close()
+	log.Printf("Yanked %s by %s: %s", target, signer, req.Reason)
+	s.announce(ctx, pb.PluginAnnouncement_KIND_YANKED, plugin)
+	s.index.Changed(plugin.Name)
+	s.search.Put(plugin)
+	return &pb.GetPluginResponse{Plugin: plugin}, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Sprintf("failed to pin: %s", resp.Status), nil
+func (s *pluginRegistryServer) GetPinStatus(ctx context.Context, req *pb.GetPinStatusRequest) (*pb.GetPinStatusResponse, error) {
+	plugin, err := s.resolvePlugin(ctx, req.Name, req.Version)
+	if err != nil {
+		return nil, err
 	}
+	return &pb.GetPinStatusResponse{
+		Name:        plugin.Name,
+		Version:     plugin.Version,
+		Cid:         plugin.Cid,
+		Pins:        plugin.Pins,
+		Replicas:    plugin.Replicas,
+		MinReplicas: plugin.MinReplicas,
+		Available:   plugin.Available,
+	}, nil
+}
 
-	return fmt.Sprintf("%s%s", gatewayUrl, hashToPin), nil
+// storeError maps metadata store errors onto gRPC status codes.
+func storeError(err error) error {
+	if errors.Is(err, store.ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
 }
@@ -0,0 +1,331 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"spacecore_registry/internal/pinning"
+	"spacecore_registry/pb"
+
+	"github.com/ipfs/boxo/files"
+	"github.com/ipfs/boxo/path"
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/multiformats/go-multihash"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// memContent is a content.Backend that keeps files in memory under raw
+// CIDs, the same ones testCID returns.
+type memContent struct {
+	mu    sync.Mutex
+	files map[string][]byte
+	adds  int
+}
+
+func newMemContent() *memContent {
+	return &memContent{files: make(map[string][]byte)}
+}
+
+func (m *memContent) Add(ctx context.Context, r io.Reader) (path.ImmutablePath, error) {
+	m.mu.Lock()
+	m.adds++
+	m.mu.Unlock()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return path.ImmutablePath{}, err
+	}
+	c, err := cid.Prefix{Version: 1, Codec: cid.Raw, MhType: multihash.SHA2_256, MhLength: -1}.Sum(data)
+	if err != nil {
+		return path.ImmutablePath{}, err
+	}
+	m.put(c.String(), data)
+	return path.FromCid(c), nil
+}
+
+func (m *memContent) put(c string, data []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[c] = data
+}
+
+func (m *memContent) Get(ctx context.Context, p path.ImmutablePath) (files.File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.files[p.RootCid().String()]
+	if !ok {
+		return nil, fmt.Errorf("%s not found", p)
+	}
+	return files.NewBytesFile(data), nil
+}
+
+func (m *memContent) AddNode(ctx context.Context, nd ipld.Node) error       { return nil }
+func (m *memContent) Pin(ctx context.Context, p path.ImmutablePath) error   { return nil }
+func (m *memContent) Unpin(ctx context.Context, p path.ImmutablePath) error { return nil }
+func (m *memContent) IsPinned(ctx context.Context, p path.ImmutablePath) (bool, error) {
+	return true, nil
+}
+func (m *memContent) Provide(ctx context.Context, p path.ImmutablePath) error { return nil }
+func (m *memContent) Ping(ctx context.Context) error                          { return nil }
+func (m *memContent) Close() error                                            { return nil }
+
+// newContentServer is newTestServer with in-memory content and a pin queue
+// without remote providers.
+func newContentServer(t *testing.T, plugins ...*pb.Plugin) (*pluginRegistryServer, *memContent) {
+	t.Helper()
+	s := newTestServer(t, plugins...)
+	q, err := pinning.NewQueue(s.store, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mem := newMemContent()
+	s.content = mem
+	s.pinQueue = q
+	return s, mem
+}
+
+// uploadStream replays frames to UploadPlugin and keeps its response.
+type uploadStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	frames []*pb.UploadPluginRequest
+	resp   *pb.RegisterPluginResponse
+}
+
+func (u *uploadStream) Context() context.Context { return u.ctx }
+
+func (u *uploadStream) Recv() (*pb.UploadPluginRequest, error) {
+	if len(u.frames) == 0 {
+		return nil, io.EOF
+	}
+	req := u.frames[0]
+	u.frames = u.frames[1:]
+	return req, nil
+}
+
+func (u *uploadStream) SendAndClose(resp *pb.RegisterPluginResponse) error {
+	u.resp = resp
+	return nil
+}
+
+// upload streams data to s as name at version, signed by key, in chunks
+// of chunkSize bytes.
+func upload(t *testing.T, s *pluginRegistryServer, key testKey, name, version string, data []byte, chunkSize int) (*pb.RegisterPluginResponse, error) {
+	t.Helper()
+	manifest, err := manifestDigest(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frames := []*pb.UploadPluginRequest{{Payload: &pb.UploadPluginRequest_Header{Header: &pb.UploadPluginHeader{
+		Name:      name,
+		Version:   version,
+		PublicKey: key.pub,
+		Signature: key.sign(t, pluginSignaturePayload(testCID(t, string(data)), name, version, nil, manifest)),
+	}}}}
+	for len(data) > 0 {
+		n := min(chunkSize, len(data))
+		frames = append(frames, &pb.UploadPluginRequest{Payload: &pb.UploadPluginRequest_Chunk{Chunk: data[:n]}})
+		data = data[n:]
+	}
+	stream := &uploadStream{ctx: context.Background(), frames: frames}
+	err = s.UploadPlugin(stream)
+	return stream.resp, err
+}
+
+func TestUploadPlugin(t *testing.T) {
+	ctx := context.Background()
+	owner := newTestKey(t, crypto.Ed25519)
+	stranger := newTestKey(t, crypto.Ed25519)
+	data := bytes.Repeat([]byte("plugin"), 100)
+
+	t.Run("registered", func(t *testing.T) {
+		s, _ := newContentServer(t)
+		resp, err := upload(t, s, owner, "ns/a", "1.0.0", data, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := sha256.Sum256(data)
+		if resp.Sha256 != hex.EncodeToString(want[:]) || resp.Size != int64(len(data)) {
+			t.Errorf("response digest = %s, %d bytes", resp.Sha256, resp.Size)
+		}
+		stored, err := s.store.GetPlugin(ctx, "ns/a", "1.0.0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Cid != "/ipfs/"+testCID(t, string(data)) || stored.Artifacts[0].Publisher != owner.id.String() {
+			t.Errorf("stored plugin = %v", stored)
+		}
+	})
+
+	t.Run("over the size limit", func(t *testing.T) {
+		s, _ := newContentServer(t)
+		s.maxUploadSize = int64(len(data)) - 1
+		_, err := upload(t, s, owner, "ns/a", "1.0.0", data, 64)
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("error = %v, want ResourceExhausted", err)
+		}
+		if _, err := s.store.GetPlugin(ctx, "ns/a", "1.0.0"); err == nil {
+			t.Error("oversized upload was registered")
+		}
+	})
+
+	t.Run("not a maintainer", func(t *testing.T) {
+		s, mem := newContentServer(t)
+		if err := s.store.CreateNamespace(ctx, &pb.Namespace{Name: "ns", Owner: owner.id.String()}); err != nil {
+			t.Fatal(err)
+		}
+		_, err := upload(t, s, stranger, "ns/a", "1.0.0", data, 64)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("error = %v, want PermissionDenied", err)
+		}
+		if mem.adds != 0 {
+			t.Errorf("content was added %d times before the publisher was checked", mem.adds)
+		}
+	})
+}
+
+func TestYankPlugin(t *testing.T) {
+	ctx := context.Background()
+	owner := newTestKey(t, crypto.Ed25519)
+	stranger := newTestKey(t, crypto.Ed25519)
+	s := newTestServer(t, &pb.Plugin{Name: "ns/a", Version: "1.0.0", Cid: "/ipfs/a"})
+	if err := s.store.CreateNamespace(ctx, &pb.Namespace{Name: "ns", Owner: owner.id.String(), AdminSequence: 1}); err != nil {
+		t.Fatal(err)
+	}
+	yank := func(key testKey, version string, sequence uint64) *pb.YankPluginRequest {
+		now := time.Now().Unix()
+		return &pb.YankPluginRequest{Name: "ns/a", Version: version, Reason: "broken", Auth: &pb.RequestSignature{
+			PublicKey: key.pub,
+			Signature: key.sign(t, adminSignaturePayload(actionYank, "ns", "ns/a:"+version, now, sequence)),
+			Timestamp: now,
+			Sequence:  sequence,
+		}}
+	}
+
+	tests := []struct {
+		name         string
+		req          *pb.YankPluginRequest
+		wantCode     codes.Code
+		wantSequence uint64
+	}{
+		{"missing version", yank(owner, "2.0.0", 2), codes.NotFound, 1},
+		{"not a maintainer", yank(stranger, "1.0.0", 2), codes.PermissionDenied, 1},
+		{"replayed sequence", yank(owner, "1.0.0", 1), codes.FailedPrecondition, 1},
+		{"yanked", yank(owner, "1.0.0", 2), codes.OK, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.YankPlugin(ctx, tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("error = %v, want code %s", err, tt.wantCode)
+			}
+			ns, err := s.store.GetNamespace(ctx, "ns")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ns.AdminSequence != tt.wantSequence {
+				t.Errorf("sequence = %d, want %d", ns.AdminSequence, tt.wantSequence)
+			}
+			stored, err := s.store.GetPlugin(ctx, "ns/a", "1.0.0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Yanked != (tt.wantCode == codes.OK) {
+				t.Errorf("stored yanked = %v", stored.Yanked)
+			}
+			if resp != nil && (!resp.Plugin.Yanked || resp.Plugin.YankReason != "broken") {
+				t.Errorf("response plugin = %v", resp.Plugin)
+			}
+		})
+	}
+}
+
+// downloadStream collects the frames DownloadPlugin sends.
+type downloadStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	frames []*pb.DownloadPluginResponse
+}
+
+func (d *downloadStream) Context() context.Context { return d.ctx }
+
+func (d *downloadStream) Send(resp *pb.DownloadPluginResponse) error {
+	// The server reuses its read buffers between frames.
+	d.frames = append(d.frames, proto.Clone(resp).(*pb.DownloadPluginResponse))
+	return nil
+}
+
+func download(s *pluginRegistryServer, req *pb.DownloadPluginRequest) ([]*pb.DownloadPluginResponse, []byte, error) {
+	stream := &downloadStream{ctx: context.Background()}
+	err := s.DownloadPlugin(req, stream)
+	var content []byte
+	for _, frame := range stream.frames {
+		content = append(content, frame.Content...)
+	}
+	return stream.frames, content, err
+}
+
+func TestDownloadPlugin(t *testing.T) {
+	key := newTestKey(t, crypto.Ed25519)
+	data := make([]byte, 2*downloadChunkSize+100)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	s, _ := newContentServer(t)
+	if _, err := upload(t, s, key, "ns/a", "1.0.0", data, 64<<10); err != nil {
+		t.Fatal(err)
+	}
+	cid := "/ipfs/" + testCID(t, string(data))
+
+	tests := []struct {
+		name     string
+		req      *pb.DownloadPluginRequest
+		wantCode codes.Code
+	}{
+		{"by name", &pb.DownloadPluginRequest{Name: "ns/a"}, codes.OK},
+		{"by cid", &pb.DownloadPluginRequest{Cid: cid}, codes.OK},
+		{"resumed", &pb.DownloadPluginRequest{Name: "ns/a", Offset: downloadChunkSize + 7}, codes.OK},
+		{"resumed at the end", &pb.DownloadPluginRequest{Cid: cid, Offset: int64(len(data))}, codes.OK},
+		{"offset past the end", &pb.DownloadPluginRequest{Cid: cid, Offset: int64(len(data)) + 1}, codes.OutOfRange},
+		{"negative offset", &pb.DownloadPluginRequest{Cid: cid, Offset: -1}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames, content, err := download(s, tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("error = %v, want code %s", err, tt.wantCode)
+			}
+			if tt.wantCode != codes.OK {
+				return
+			}
+			first := frames[0]
+			if first.Cid != cid || first.TotalSize != int64(len(data)) || first.Offset != tt.req.Offset {
+				t.Errorf("first frame = cid %s, size %d, offset %d", first.Cid, first.TotalSize, first.Offset)
+			}
+			if first.Publisher != key.id.String() {
+				t.Errorf("first frame publisher = %q, want %s", first.Publisher, key.id)
+			}
+			offset := tt.req.Offset
+			for _, frame := range frames {
+				if frame.Offset != offset {
+					t.Errorf("frame offset = %d, want %d", frame.Offset, offset)
+				}
+				offset += int64(len(frame.Content))
+			}
+			if !bytes.Equal(content, data[tt.req.Offset:]) {
+				t.Errorf("downloaded %d bytes that differ from the %d uploaded after offset %d", len(content), len(data[tt.req.Offset:]), tt.req.Offset)
+			}
+		})
+	}
+}
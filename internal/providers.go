@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"spacecore_registry/pb"
+
+	"github.com/ipfs/boxo/path"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// reprovideInterval keeps provider records, which expire after 48h on
+	// the public DHT, comfortably fresh.
+	reprovideInterval = 12 * time.Hour
+
+	defaultProviderLimit = 20
+	maxProviderLimit     = 100
+	findProvidersTimeout = 30 * time.Second
+)
+
+// runReprovider announces every registered plugin CID on startup and then
+// every interval, until ctx is cancelled.
+func (s *pluginRegistryServer) runReprovider(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.reprovide(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *pluginRegistryServer) reprovide(ctx context.Context) {
+	plugins, err := s.store.ListPlugins(ctx)
+	if err != nil {
+		log.Printf("reprovide: failed to list plugins: %v", err)
+		return
+	}
+	seen := make(map[string]bool)
+	var provided int
+	for _, plugin := range plugins {
+		if ctx.Err() != nil {
+			return
+		}
+		for _, artifact := range artifactsOf(plugin) {
+			p, err := cidPath(artifact.Cid)
+			if err != nil || seen[p.String()] {
+				continue
+			}
+			seen[p.String()] = true
+			if err := s.content.Provide(ctx, p); err != nil {
+				log.Printf("reprovide: failed to provide %s: %v", p, err)
+				continue
+			}
+			provided++
+		}
+	}
+	log.Printf("Reprovided %d of %d plugin CIDs", provided, len(seen))
+}
+
+// pluginPath parses the CID stored on a plugin record.
+func pluginPath(plugin *pb.Plugin) (path.ImmutablePath, error) {
+	return cidPath(plugin.Cid)
+}
+
+// cidPath parses a stored "/ipfs/<cid>" path.
+func cidPath(c string) (path.ImmutablePath, error) {
+	p, err := path.NewPath(c)
+	if err != nil {
+		return path.ImmutablePath{}, err
+	}
+	return path.NewImmutablePath(p)
+}
+
+func (s *pluginRegistryServer) FindProviders(ctx context.Context, req *pb.FindProvidersRequest) (*pb.FindProvidersResponse, error) {
+	plugin, err := s.resolvePlugin(ctx, req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	p, err := pluginPath(plugin)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "stored CID %q is invalid: %v", plugin.Cid, err)
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultProviderLimit
+	}
+	limit = min(limit, maxProviderLimit)
+
+	ctx, cancel := context.WithTimeout(ctx, findProvidersTimeout)
+	defer cancel()
+	resp := &pb.FindProvidersResponse{
+		Name:    plugin.Name,
+		Version: plugin.Version,
+		Cid:     plugin.Cid,
+	}
+	for info := range s.dht.FindProvidersAsync(ctx, p.RootCid(), limit) {
+		addrs := make([]string, 0, len(info.Addrs))
+		for _, addr := range info.Addrs {
+			addrs = append(addrs, addr.String())
+		}
+		resp.Providers = append(resp.Providers, &pb.PeerInfo{Id: info.ID.String(), Addrs: addrs})
+	}
+	return resp, nil
+}
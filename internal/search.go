@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"context"
+	"encoding/base64"
+	"log"
+	"strconv"
+
+	"spacecore_registry/internal/search"
+	"spacecore_registry/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *pluginRegistryServer) SearchPlugins(ctx context.Context, req *pb.SearchPluginsRequest) (*pb.SearchPluginsResponse, error) {
+	if req.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+	query, err := search.ParseQuery(req.Query, req.Filters)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	offset, err := decodeSearchToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	results, facets := s.search.Search(query)
+	resp := &pb.SearchPluginsResponse{
+		Facets:     facets,
+		TotalCount: int32(len(results)),
+	}
+	if offset >= len(results) {
+		return resp, nil
+	}
+	end := min(offset+pageLimit(req.PageSize), len(results))
+	if end < len(results) {
+		resp.NextPageToken = encodeSearchToken(end)
+	}
+	resp.Results = results[offset:end]
+
+	// The index only tracks what search needs; return the current records
+	// so pin and replication state are up to date.
+	for _, result := range resp.Results {
+		plugin, err := s.store.GetPlugin(ctx, result.Plugin.Name, result.Plugin.Version)
+		if err != nil {
+			return nil, storeError(err)
+		}
+		result.Plugin = plugin
+	}
+	return resp, nil
+}
+
+// Search pages are addressed by offset into the ranked results, which may
+// shift if plugins are registered or downloaded in between.
+func encodeSearchToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeSearchToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	return offset, nil
+}
+
+// countDownload records a completed download of a registered plugin.
+func (s *pluginRegistryServer) countDownload(ctx context.Context, plugin *pb.Plugin) {
+	downloads, err := s.store.CountDownload(ctx, plugin.Name, plugin.Version)
+	if err != nil {
+		log.Printf("failed to count download of %s:%s: %v", plugin.Name, plugin.Version, err)
+		return
+	}
+	plugin.Downloads = downloads
+	s.search.Put(plugin)
+}
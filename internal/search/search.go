@@ -0,0 +1,372 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"spacecore_registry/internal/store"
+	"spacecore_registry/pb"
+
+	"github.com/Masterminds/semver/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// Filter keys accepted by ParseQuery.
+const (
+	FacetOS        = "os"
+	FacetArch      = "arch"
+	FacetPublisher = "publisher"
+	FacetKeyword   = "keyword"
+)
+
+// facetKeys are reported in search responses, in this order. Keywords are
+// filterable but too numerous to be worth counting.
+var facetKeys = []string{FacetOS, FacetArch, FacetPublisher}
+
+type field int
+
+const (
+	fieldName field = iota
+	fieldKeywords
+	fieldDescription
+	fieldPublisher
+	fieldPlatform
+	numFields
+)
+
+// fieldWeights scale a match by where it was found.
+var fieldWeights = [numFields]float64{
+	fieldName:        3,
+	fieldKeywords:    2,
+	fieldDescription: 1,
+	fieldPublisher:   1,
+	fieldPlatform:    1,
+}
+
+// Match quality of a single query term against a single token.
+const (
+	exactMatch  = 1.0
+	prefixMatch = 0.6
+	fuzzyMatch  = 0.4
+)
+
+// Query is a parsed search request.
+type Query struct {
+	Terms   []string
+	Filters map[string][]string
+}
+
+// ParseQuery splits free text into lowercase terms, treating "key=value"
+// words as filters alongside the explicit filters.
+func ParseQuery(text string, filters []string) (Query, error) {
+	q := Query{Filters: make(map[string][]string)}
+	for _, word := range strings.Fields(text) {
+		if strings.Contains(word, "=") {
+			filters = append(filters, word)
+			continue
+		}
+		q.Terms = append(q.Terms, tokenize(word)...)
+	}
+	for _, filter := range filters {
+		key, value, ok := strings.Cut(filter, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.ToLower(strings.TrimSpace(value))
+		if !ok || value == "" {
+			return Query{}, fmt.Errorf("invalid filter %q, want key=value", filter)
+		}
+		switch key {
+		case FacetOS, FacetArch, FacetPublisher, FacetKeyword:
+		default:
+			return Query{}, fmt.Errorf("unknown filter key %q", key)
+		}
+		q.Filters[key] = append(q.Filters[key], value)
+	}
+	return q, nil
+}
+
+// Index is an in-memory search index over locally registered plugins. Each
+// plugin name is one document, described by its latest non-yanked version
+// and ranked with the downloads of all its versions.
+type Index struct {
+	mu      sync.RWMutex
+	entries map[string]*entry
+}
+
+type entry struct {
+	versions  map[string]*pb.Plugin
+	latest    *pb.Plugin
+	downloads int64
+	tokens    [numFields][]string
+	facets    map[string][]string
+}
+
+func NewIndex() *Index {
+	return &Index{entries: make(map[string]*entry)}
+}
+
+// Build returns an index of every plugin in the store.
+func Build(ctx context.Context, metadataStore store.MetadataStore) (*Index, error) {
+	plugins, err := metadataStore.ListPlugins(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plugins: %w", err)
+	}
+	idx := NewIndex()
+	for _, plugin := range plugins {
+		idx.Put(plugin)
+	}
+	return idx, nil
+}
+
+// Put adds or replaces a plugin version.
+func (i *Index) Put(plugin *pb.Plugin) {
+	plugin = proto.Clone(plugin).(*pb.Plugin)
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	e, ok := i.entries[plugin.Name]
+	if !ok {
+		e = &entry{versions: make(map[string]*pb.Plugin)}
+		i.entries[plugin.Name] = e
+	}
+	e.versions[plugin.Version] = plugin
+	e.refresh()
+}
+
+// refresh recomputes the document after one of its versions changed.
+func (e *entry) refresh() {
+	e.latest = nil
+	e.downloads = 0
+	var best *semver.Version
+	for _, plugin := range e.versions {
+		e.downloads += plugin.Downloads
+		if plugin.Yanked {
+			continue
+		}
+		v, err := semver.NewVersion(plugin.Version)
+		if err != nil {
+			continue
+		}
+		if best == nil || preferred(v, best) {
+			best, e.latest = v, plugin
+		}
+	}
+	e.tokens = [numFields][]string{}
+	e.facets = make(map[string][]string)
+	if e.latest == nil {
+		return
+	}
+
+	p := e.latest
+	e.tokens[fieldName] = tokenize(p.Name)
+	namespace, _, _ := strings.Cut(p.Name, "/")
+	e.tokens[fieldPublisher] = []string{strings.ToLower(namespace)}
+	e.facets[FacetPublisher] = []string{strings.ToLower(namespace)}
+	if p.Publisher != "" {
+		e.tokens[fieldPublisher] = append(e.tokens[fieldPublisher], strings.ToLower(p.Publisher))
+	}
+	if m := p.Manifest; m != nil {
+		e.tokens[fieldDescription] = tokenize(m.Description)
+		for _, keyword := range m.Keywords {
+			e.tokens[fieldKeywords] = append(e.tokens[fieldKeywords], tokenize(keyword)...)
+			e.facets[FacetKeyword] = appendUnique(e.facets[FacetKeyword], strings.ToLower(keyword))
+		}
+	}
+	for _, platform := range p.Platforms {
+		os, arch := strings.ToLower(platform.Os), strings.ToLower(platform.Arch)
+		e.tokens[fieldPlatform] = append(e.tokens[fieldPlatform], os, arch)
+		e.facets[FacetOS] = appendUnique(e.facets[FacetOS], os)
+		e.facets[FacetArch] = appendUnique(e.facets[FacetArch], arch)
+	}
+}
+
+// preferred reports whether v should describe the plugin rather than best.
+// Stable releases win over newer prereleases.
+func preferred(v, best *semver.Version) bool {
+	vStable, bestStable := v.Prerelease() == "", best.Prerelease() == ""
+	if vStable != bestStable {
+		return vStable
+	}
+	return v.GreaterThan(best)
+}
+
+// matchFilters reports whether the document satisfies every filter key.
+func (e *entry) matchFilters(filters map[string][]string, publisher string) bool {
+	for key, values := range filters {
+		found := false
+		for _, value := range values {
+			if key == FacetPublisher && value == publisher {
+				found = true
+				break
+			}
+			if slices.Contains(e.facets[key], value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// relevance scores the document against the query terms. Every term must
+// match somewhere; zero means no match.
+func (e *entry) relevance(terms []string) float64 {
+	if len(terms) == 0 {
+		return 1
+	}
+	var total float64
+	for _, term := range terms {
+		var best float64
+		for f := field(0); f < numFields; f++ {
+			for _, token := range e.tokens[f] {
+				best = max(best, fieldWeights[f]*matchToken(term, token))
+			}
+		}
+		if best == 0 {
+			return 0
+		}
+		total += best
+	}
+	return total
+}
+
+// Search returns the matching documents, best first, and facet counts over
+// all of them.
+func (i *Index) Search(q Query) ([]*pb.SearchResult, []*pb.Facet) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var results []*pb.SearchResult
+	counts := make(map[string]map[string]int32)
+	for _, e := range i.entries {
+		if e.latest == nil {
+			continue
+		}
+		if !e.matchFilters(q.Filters, strings.ToLower(e.latest.Publisher)) {
+			continue
+		}
+		relevance := e.relevance(q.Terms)
+		if relevance == 0 {
+			continue
+		}
+		// Popularity scales relevance logarithmically so a heavily used
+		// plugin can't bury a much better textual match.
+		score := relevance * (1 + math.Log10(1+float64(e.downloads)))
+		results = append(results, &pb.SearchResult{
+			Plugin:    proto.Clone(e.latest).(*pb.Plugin),
+			Score:     score,
+			Downloads: e.downloads,
+		})
+		for _, key := range facetKeys {
+			if counts[key] == nil {
+				counts[key] = make(map[string]int32)
+			}
+			for _, value := range e.facets[key] {
+				counts[key][value]++
+			}
+		}
+	}
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		return results[a].Plugin.Name < results[b].Plugin.Name
+	})
+
+	var facets []*pb.Facet
+	for _, key := range facetKeys {
+		if len(counts[key]) == 0 {
+			continue
+		}
+		facet := &pb.Facet{Key: key}
+		for value, count := range counts[key] {
+			facet.Values = append(facet.Values, &pb.FacetCount{Value: value, Count: count})
+		}
+		sort.Slice(facet.Values, func(a, b int) bool {
+			if facet.Values[a].Count != facet.Values[b].Count {
+				return facet.Values[a].Count > facet.Values[b].Count
+			}
+			return facet.Values[a].Value < facet.Values[b].Value
+		})
+		facets = append(facets, facet)
+	}
+	return results, facets
+}
+
+// matchToken grades a query term against an indexed token.
+func matchToken(term, token string) float64 {
+	switch {
+	case term == token:
+		return exactMatch
+	case strings.HasPrefix(token, term):
+		return prefixMatch
+	}
+	// Short terms are too ambiguous to correct.
+	allowed := 0
+	switch {
+	case len(term) >= 8:
+		allowed = 2
+	case len(term) >= 4:
+		allowed = 1
+	}
+	if allowed > 0 && editDistance(term, token, allowed) <= allowed {
+		return fuzzyMatch
+	}
+	return 0
+}
+
+// editDistance is the edit distance between a and b, counting an adjacent
+// transposition as one edit, or limit+1 once it is known to exceed limit.
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > limit || -d > limit {
+		return limit + 1
+	}
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+// tokenize lowercases s and splits it on anything but letters and digits.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" || slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
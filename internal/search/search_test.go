@@ -0,0 +1,150 @@
+package search
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	"spacecore_registry/pb"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		filters []string
+		want    Query
+		wantErr bool
+	}{
+		{"terms", "IPFS Pinning-Service", nil, Query{Terms: []string{"ipfs", "pinning", "service"}, Filters: map[string][]string{}}, false},
+		{"inline filters", "storage os=Linux arch=amd64", []string{"publisher=acme"}, Query{
+			Terms:   []string{"storage"},
+			Filters: map[string][]string{"os": {"linux"}, "arch": {"amd64"}, "publisher": {"acme"}},
+		}, false},
+		{"repeated filter", "", []string{"os=linux", " OS = darwin "}, Query{Filters: map[string][]string{"os": {"linux", "darwin"}}}, false},
+		{"unknown key", "license=mit", nil, Query{}, true},
+		{"empty value", "", []string{"os="}, Query{}, true},
+		{"not a filter", "", []string{"linux"}, Query{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuery(tt.text, tt.filters)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuery = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func testIndex() *Index {
+	idx := NewIndex()
+	for _, p := range []*pb.Plugin{
+		{Name: "acme/ipfs-pin", Version: "1.0.0", Downloads: 10, Platforms: []*pb.Platform{{Os: "linux", Arch: "amd64"}},
+			Manifest: &pb.PluginManifest{Description: "Pins content to IPFS", Keywords: []string{"storage"}}},
+		{Name: "acme/ipfs-pin", Version: "2.0.0-beta.1", Downloads: 5,
+			Manifest: &pb.PluginManifest{Description: "Beta rewrite"}},
+		{Name: "acme/logger", Version: "1.0.0", Downloads: 1000, Platforms: []*pb.Platform{{Os: "darwin", Arch: "arm64"}, {Os: "linux", Arch: "arm64"}},
+			Manifest: &pb.PluginManifest{Description: "Structured logging, with optional storage of logs"}},
+		{Name: "beta/storage", Version: "0.1.0", Downloads: 15, Platforms: []*pb.Platform{{Os: "linux", Arch: "amd64"}}},
+		{Name: "gone/storage", Version: "1.0.0", Yanked: true},
+	} {
+		idx.Put(p)
+	}
+	return idx
+}
+
+func TestIndexSearch(t *testing.T) {
+	idx := testIndex()
+	tests := []struct {
+		name    string
+		text    string
+		filters []string
+		want    []string
+	}{
+		{"most downloaded first", "", nil, []string{"acme/logger", "acme/ipfs-pin", "beta/storage"}},
+		{"name beats keyword and description", "storage", nil, []string{"beta/storage", "acme/ipfs-pin", "acme/logger"}},
+		{"prefix", "log", nil, []string{"acme/logger"}},
+		{"typo", "storgae", nil, []string{"beta/storage", "acme/ipfs-pin", "acme/logger"}},
+		{"every term must match", "ipfs logger", nil, nil},
+		{"os filter", "", []string{"os=darwin"}, []string{"acme/logger"}},
+		{"filter values are alternatives", "", []string{"arch=arm64", "arch=amd64"}, []string{"acme/logger", "acme/ipfs-pin", "beta/storage"}},
+		{"publisher filter", "", []string{"publisher=beta"}, []string{"beta/storage"}},
+		{"keyword filter", "", []string{"keyword=storage"}, []string{"acme/ipfs-pin"}},
+		{"filters combine", "storage", []string{"os=linux", "arch=amd64"}, []string{"beta/storage", "acme/ipfs-pin"}},
+		{"describes the latest stable version", "beta rewrite", nil, nil},
+		{"yanked versions are not found", "gone", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQuery(tt.text, tt.filters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			results, _ := idx.Search(q)
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Plugin.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Search = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexFacets(t *testing.T) {
+	_, facets := testIndex().Search(Query{})
+	got := make(map[string][]string)
+	for _, f := range facets {
+		for _, v := range f.Values {
+			got[f.Key] = append(got[f.Key], fmt.Sprintf("%s=%d", v.Value, v.Count))
+		}
+	}
+	want := map[string][]string{
+		FacetOS:        {"linux=3", "darwin=1"},
+		FacetArch:      {"amd64=2", "arm64=1"},
+		FacetPublisher: {"acme=2", "beta=1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("facets = %v, want %v", got, want)
+	}
+}
+
+func TestIndexPutReplacesVersion(t *testing.T) {
+	idx := testIndex()
+	idx.Put(&pb.Plugin{Name: "acme/ipfs-pin", Version: "1.0.0", Yanked: true})
+	results, _ := idx.Search(Query{Terms: []string{"ipfs"}})
+	if len(results) != 1 || results[0].Plugin.Version != "2.0.0-beta.1" {
+		t.Fatalf("Search after yank = %v, want the pre-release to describe the plugin", results)
+	}
+	if results[0].Downloads != 5 {
+		t.Errorf("downloads = %d, want 5", results[0].Downloads)
+	}
+}
+
+func TestMatchToken(t *testing.T) {
+	tests := []struct {
+		term, token string
+		want        float64
+	}{
+		{"ipfs", "ipfs", exactMatch},
+		{"ip", "ipfs", prefixMatch},
+		{"ipfz", "ipfs", fuzzyMatch},
+		{"storgae", "storage", fuzzyMatch},
+		{"ipz", "ips", 0},
+		{"ipfzz", "ipfs", 0},
+		{"pinnig", "pinning", fuzzyMatch},
+		{"pinnign", "pinning", fuzzyMatch},
+		{"pixxing", "pinning", 0},
+		{"pinninnng", "pinning", fuzzyMatch},
+	}
+	for _, tt := range tests {
+		if got := matchToken(tt.term, tt.token); got != tt.want {
+			t.Errorf("matchToken(%q, %q) = %v, want %v", tt.term, tt.token, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"spacecore_registry/pb"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	cryptopb "github.com/libp2p/go-libp2p/core/crypto/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pluginSignaturePayload is the message a publisher signs to vouch for one
+// artifact of a plugin. cid is the bare root CID (no /ipfs/ prefix), version
+// is the canonical semver form, platforms are the artifact's normalized
+// platforms and manifest is the version's manifestDigest, so hosts can
+// rebuild it from a pb.Plugin and neither the labels they select builds by
+// nor the permissions the plugin asks for can be changed.
+func pluginSignaturePayload(cid, name, version string, platforms []*pb.Platform, manifest string) []byte {
+	return []byte(fmt.Sprintf("spacecore-registry/plugin/v2\n%s\n%s\n%s\n%s\n%s", cid, name, version, signedPlatforms(platforms), manifest))
+}
+
+// signedPlatforms lists platforms as sorted "os/arch" or "os" entries joined
+// by commas, empty for a build that runs anywhere.
+func signedPlatforms(platforms []*pb.Platform) string {
+	names := make([]string, 0, len(platforms))
+	for _, p := range platforms {
+		names = append(names, platformString(p))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// verifySignature checks a detached signature made with a libp2p ed25519 or
+// secp256k1 key and returns the signer's peer ID.
+func verifySignature(publicKey, signature, payload []byte) (peer.ID, error) {
+	key, id, err := signerKey(publicKey, signature)
+	if err != nil {
+		return "", err
+	}
+	ok, err := key.Verify(payload, signature)
+	if err != nil || !ok {
+		return "", status.Error(codes.Unauthenticated, "signature verification failed")
+	}
+	return id, nil
+}
+
+// signerKey parses the public key of a signed request without checking the
+// signature, so a request can be turned away before its payload is known.
+func signerKey(publicKey, signature []byte) (crypto.PubKey, peer.ID, error) {
+	if len(publicKey) == 0 || len(signature) == 0 {
+		return nil, "", status.Error(codes.Unauthenticated, "signature and public key are required")
+	}
+	key, err := crypto.UnmarshalPublicKey(publicKey)
+	if err != nil {
+		return nil, "", status.Errorf(codes.InvalidArgument, "invalid public key: %v", err)
+	}
+	switch key.Type() {
+	case cryptopb.KeyType_Ed25519, cryptopb.KeyType_Secp256k1:
+	default:
+		return nil, "", status.Errorf(codes.InvalidArgument, "unsupported key type %s, want Ed25519 or Secp256k1", key.Type())
+	}
+	id, err := peer.IDFromPublicKey(key)
+	if err != nil {
+		return nil, "", status.Errorf(codes.InvalidArgument, "cannot derive peer ID: %v", err)
+	}
+	return key, id, nil
+}
+
+// maxSignatureSkew bounds how old or far in the future an admin request
+// timestamp may be. Replay within the window is prevented by the namespace's
+// request sequence; see useSequence.
+const maxSignatureSkew = 5 * time.Minute
+
+// adminSignaturePayload is the message an owner signs to authorize an
+// administrative action on a namespace.
+func adminSignaturePayload(action, namespace, target string, timestamp int64, sequence uint64) []byte {
+	return []byte(fmt.Sprintf("spacecore-registry/admin/v2\n%s\n%s\n%s\n%d\n%d", action, namespace, target, timestamp, sequence))
+}
+
+// verifyRequestSignature checks an admin request signature and returns the
+// signer's peer ID.
+func verifyRequestSignature(auth *pb.RequestSignature, action, namespace, target string) (peer.ID, error) {
+	if auth == nil {
+		return "", status.Error(codes.Unauthenticated, "request signature is required")
+	}
+	skew := time.Since(time.Unix(auth.Timestamp, 0))
+	if skew > maxSignatureSkew || skew < -maxSignatureSkew {
+		return "", status.Error(codes.Unauthenticated, "request timestamp is outside the allowed window")
+	}
+	payload := adminSignaturePayload(action, namespace, target, auth.Timestamp, auth.Sequence)
+	return verifySignature(auth.PublicKey, auth.Signature, payload)
+}
+
+// useSequence records the sequence of a verified admin request on ns,
+// rejecting one that was already used. It must run inside the namespace
+// update that applies the request.
+func useSequence(ns *pb.Namespace, auth *pb.RequestSignature) error {
+	if auth.Sequence <= ns.AdminSequence {
+		return status.Errorf(codes.FailedPrecondition, "request sequence %d was already used on namespace %s, which is at %d", auth.Sequence, ns.Name, ns.AdminSequence)
+	}
+	ns.AdminSequence = auth.Sequence
+	return nil
+}
@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"spacecore_registry/pb"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testKey struct {
+	priv crypto.PrivKey
+	pub  []byte
+	id   peer.ID
+}
+
+func newTestKey(t *testing.T, typ int) testKey {
+	t.Helper()
+	priv, pub, err := crypto.GenerateKeyPairWithReader(typ, 2048, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := crypto.MarshalPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := peer.IDFromPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testKey{priv: priv, pub: raw, id: id}
+}
+
+func (k testKey) sign(t *testing.T, payload []byte) []byte {
+	t.Helper()
+	sig, err := k.priv.Sign(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func TestVerifySignature(t *testing.T) {
+	ed := newTestKey(t, crypto.Ed25519)
+	secp := newTestKey(t, crypto.Secp256k1)
+	ecdsa := newTestKey(t, crypto.ECDSA)
+	payload := pluginSignaturePayload("bafy", "a", "1.0.0", nil, "")
+
+	tests := []struct {
+		name      string
+		publicKey []byte
+		signature []byte
+		payload   []byte
+		wantID    peer.ID
+		wantCode  codes.Code
+	}{
+		{"ed25519", ed.pub, ed.sign(t, payload), payload, ed.id, codes.OK},
+		{"secp256k1", secp.pub, secp.sign(t, payload), payload, secp.id, codes.OK},
+		{"other payload", ed.pub, ed.sign(t, payload), pluginSignaturePayload("bafy", "a", "1.0.1", nil, ""), "", codes.Unauthenticated},
+		{"other key", secp.pub, ed.sign(t, payload), payload, "", codes.Unauthenticated},
+		{"missing signature", ed.pub, nil, payload, "", codes.Unauthenticated},
+		{"missing key", nil, ed.sign(t, payload), payload, "", codes.Unauthenticated},
+		{"garbage key", []byte("not a key"), ed.sign(t, payload), payload, "", codes.InvalidArgument},
+		{"unsupported key type", ecdsa.pub, ecdsa.sign(t, payload), payload, "", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := verifySignature(tt.publicKey, tt.signature, tt.payload)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("error = %v, want code %s", err, tt.wantCode)
+			}
+			if id != tt.wantID {
+				t.Errorf("signer = %s, want %s", id, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestPluginSignaturePayload(t *testing.T) {
+	a := pluginSignaturePayload("bafy", "a", "1.0.0", []*pb.Platform{{Os: "linux", Arch: "amd64"}, {Os: "darwin"}}, "abc")
+	b := pluginSignaturePayload("bafy", "a", "1.0.0", []*pb.Platform{{Os: "darwin"}, {Os: "linux", Arch: "amd64"}}, "abc")
+	if string(a) != string(b) {
+		t.Errorf("payload depends on platform order:\n%s\n%s", a, b)
+	}
+	want := "spacecore-registry/plugin/v2\nbafy\na\n1.0.0\ndarwin,linux/amd64\nabc"
+	if string(a) != want {
+		t.Errorf("payload = %q, want %q", a, want)
+	}
+	for _, other := range [][]byte{
+		pluginSignaturePayload("bafy", "a", "1.0.0", []*pb.Platform{{Os: "linux"}}, "abc"),
+		pluginSignaturePayload("bafy", "a", "1.0.0", []*pb.Platform{{Os: "darwin"}, {Os: "linux", Arch: "amd64"}}, "abd"),
+	} {
+		if string(other) == want {
+			t.Errorf("payload %q does not cover platforms and manifest", other)
+		}
+	}
+}
+
+func TestVerifyRequestSignature(t *testing.T) {
+	key := newTestKey(t, crypto.Ed25519)
+	now := time.Now().Unix()
+	signed := func(action string, timestamp int64, sequence uint64) *pb.RequestSignature {
+		return &pb.RequestSignature{
+			PublicKey: key.pub,
+			Signature: key.sign(t, adminSignaturePayload(action, "ns", "bob", timestamp, sequence)),
+			Timestamp: timestamp,
+			Sequence:  sequence,
+		}
+	}
+	tampered := signed(actionAddMaintainer, now, 1)
+	tampered.Sequence = 2
+
+	tests := []struct {
+		name     string
+		auth     *pb.RequestSignature
+		wantCode codes.Code
+	}{
+		{"valid", signed(actionAddMaintainer, now, 1), codes.OK},
+		{"slight skew", signed(actionAddMaintainer, now+60, 1), codes.OK},
+		{"missing", nil, codes.Unauthenticated},
+		{"other action", signed(actionRemoveMaintainer, now, 1), codes.Unauthenticated},
+		{"sequence changed", tampered, codes.Unauthenticated},
+		{"too old", signed(actionAddMaintainer, now-int64(maxSignatureSkew.Seconds())-60, 1), codes.Unauthenticated},
+		{"too far ahead", signed(actionAddMaintainer, now+int64(maxSignatureSkew.Seconds())+60, 1), codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := verifyRequestSignature(tt.auth, actionAddMaintainer, "ns", "bob")
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("error = %v, want code %s", err, tt.wantCode)
+			}
+			if err == nil && id != key.id {
+				t.Errorf("signer = %s, want %s", id, key.id)
+			}
+		})
+	}
+}
+
+func TestUseSequence(t *testing.T) {
+	ns := &pb.Namespace{Name: "ns", AdminSequence: 5}
+	tests := []struct {
+		sequence uint64
+		wantCode codes.Code
+		wantNext uint64
+	}{
+		{5, codes.FailedPrecondition, 5},
+		{3, codes.FailedPrecondition, 5},
+		{6, codes.OK, 6},
+		{6, codes.FailedPrecondition, 6},
+		{100, codes.OK, 100},
+	}
+	for _, tt := range tests {
+		err := useSequence(ns, &pb.RequestSignature{Sequence: tt.sequence})
+		if code := status.Code(err); code != tt.wantCode || ns.AdminSequence != tt.wantNext {
+			t.Errorf("useSequence(%d) = %v, sequence now %d, want %s and %d", tt.sequence, err, ns.AdminSequence, tt.wantCode, tt.wantNext)
+		}
+	}
+}
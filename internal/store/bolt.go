@@ -0,0 +1,447 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"spacecore_registry/pb"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	pluginsBucket = []byte("plugins")
+	// cidsBucket maps a bare root CID to "<name>\x00<version>".
+	cidsBucket       = []byte("cids")
+	namespacesBucket = []byte("namespaces")
+	pinJobsBucket    = []byte("pinjobs")
+	// downloadsBucket maps "<name>\x00<version>" to the version's download
+	// count, kept out of the plugin record so counting never rewrites it.
+	downloadsBucket = []byte("downloads")
+	// metaBucket holds counters maintained alongside the other buckets.
+	metaBucket = []byte("meta")
+	// pluginCountKey is the number of plugin versions stored, kept so
+	// listings don't have to walk every name to report a total.
+	pluginCountKey = []byte("plugin-count")
+)
+
+// BoltStore is an embedded, file-backed MetadataStore for nodes that cannot
+// run Redis. Each plugin name gets a nested bucket keyed by version.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt database %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{pluginsBucket, cidsBucket, namespacesBucket, pinJobsBucket, downloadsBucket, metaBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return initPluginCount(tx)
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltStore{db: db}, nil
+}
+
+// initPluginCount counts the stored plugin versions once, for databases
+// created before the counter was kept.
+func initPluginCount(tx *bolt.Tx) error {
+	if tx.Bucket(metaBucket).Get(pluginCountKey) != nil {
+		return nil
+	}
+	var count int64
+	root := tx.Bucket(pluginsBucket)
+	err := root.ForEachBucket(func(name []byte) error {
+		count += int64(root.Bucket(name).Stats().KeyN)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return putPluginCount(tx, count)
+}
+
+func pluginCount(tx *bolt.Tx) int64 {
+	return getCounter(tx.Bucket(metaBucket), pluginCountKey)
+}
+
+func putPluginCount(tx *bolt.Tx, count int64) error {
+	return putCounter(tx.Bucket(metaBucket), pluginCountKey, count)
+}
+
+func getCounter(bucket *bolt.Bucket, key []byte) int64 {
+	value := bucket.Get(key)
+	if len(value) != 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(value))
+}
+
+func putCounter(bucket *bolt.Bucket, key []byte, count int64) error {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(count))
+	return bucket.Put(key, value)
+}
+
+func versionRef(name, version string) []byte {
+	return []byte(name + "\x00" + version)
+}
+
+// decodePlugin unmarshals a stored plugin record and fills in its download
+// count.
+func decodePlugin(tx *bolt.Tx, name, version string, value []byte) (*pb.Plugin, error) {
+	var plugin pb.Plugin
+	if err := json.Unmarshal(value, &plugin); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal plugin %s:%s: %w", name, version, err)
+	}
+	plugin.Downloads = getCounter(tx.Bucket(downloadsBucket), versionRef(name, version))
+	return &plugin, nil
+}
+
+func (b *BoltStore) CreatePlugin(ctx context.Context, plugin *pb.Plugin) error {
+	value, err := json.Marshal(plugin)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		versions, err := tx.Bucket(pluginsBucket).CreateBucketIfNotExists([]byte(plugin.Name))
+		if err != nil {
+			return err
+		}
+		if versions.Get([]byte(plugin.Version)) != nil {
+			return ErrExists
+		}
+		if err := versions.Put([]byte(plugin.Version), value); err != nil {
+			return err
+		}
+		if err := putPluginCount(tx, pluginCount(tx)+1); err != nil {
+			return err
+		}
+		ref := plugin.Name + "\x00" + plugin.Version
+		return tx.Bucket(cidsBucket).Put([]byte(rootCID(plugin.Cid)), []byte(ref))
+	})
+}
+
+func (b *BoltStore) UpdatePlugin(ctx context.Context, name, version string, fn func(*pb.Plugin) error) (*pb.Plugin, error) {
+	var plugin *pb.Plugin
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		plugin, err = getPlugin(tx, name, version)
+		if err != nil {
+			return err
+		}
+		if err := fn(plugin); err != nil {
+			return err
+		}
+		return putPlugin(tx, plugin)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return plugin, nil
+}
+
+// putPlugin overwrites a stored plugin version. Downloads live in their own
+// counter and are left out of the record.
+func putPlugin(tx *bolt.Tx, plugin *pb.Plugin) error {
+	downloads := plugin.Downloads
+	plugin.Downloads = 0
+	value, err := json.Marshal(plugin)
+	plugin.Downloads = downloads
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(pluginsBucket).Bucket([]byte(plugin.Name)).Put([]byte(plugin.Version), value)
+}
+
+func (b *BoltStore) GetPlugin(ctx context.Context, name, version string) (*pb.Plugin, error) {
+	var plugin *pb.Plugin
+	err := b.db.View(func(tx *bolt.Tx) error {
+		var err error
+		plugin, err = getPlugin(tx, name, version)
+		return err
+	})
+	return plugin, err
+}
+
+func getPlugin(tx *bolt.Tx, name, version string) (*pb.Plugin, error) {
+	versions := tx.Bucket(pluginsBucket).Bucket([]byte(name))
+	if versions == nil {
+		return nil, ErrNotFound
+	}
+	value := versions.Get([]byte(version))
+	if value == nil {
+		return nil, ErrNotFound
+	}
+	return decodePlugin(tx, name, version, value)
+}
+
+func (b *BoltStore) GetPluginByCID(ctx context.Context, cid string) (*pb.Plugin, error) {
+	var plugin *pb.Plugin
+	err := b.db.View(func(tx *bolt.Tx) error {
+		ref := tx.Bucket(cidsBucket).Get([]byte(cid))
+		if ref == nil {
+			return ErrNotFound
+		}
+		name, version, _ := strings.Cut(string(ref), "\x00")
+		var err error
+		plugin, err = getPlugin(tx, name, version)
+		return err
+	})
+	return plugin, err
+}
+
+func (b *BoltStore) IndexCID(ctx context.Context, cid, name, version string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(cidsBucket).Put([]byte(cid), []byte(name+"\x00"+version))
+	})
+}
+
+func (b *BoltStore) ListPlugins(ctx context.Context) ([]*pb.Plugin, error) {
+	var plugins []*pb.Plugin
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(pluginsBucket).ForEachBucket(func(name []byte) error {
+			versions := tx.Bucket(pluginsBucket).Bucket(name)
+			return versions.ForEach(func(version, value []byte) error {
+				plugin, err := decodePlugin(tx, string(name), string(version), value)
+				if err != nil {
+					return err
+				}
+				plugins = append(plugins, plugin)
+				return nil
+			})
+		})
+	})
+	return plugins, err
+}
+
+func (b *BoltStore) ListPluginsPage(ctx context.Context, after PageCursor, limit int) ([]*pb.Plugin, int, error) {
+	var plugins []*pb.Plugin
+	var total int
+	err := b.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(pluginsBucket)
+		c := root.Cursor()
+		// Bucket keys are sorted bytewise, which gives the name order.
+		for name, value := c.Seek([]byte(after.Name)); name != nil && len(plugins) < limit; name, value = c.Next() {
+			if value != nil {
+				continue
+			}
+			bucket := root.Bucket(name)
+			var versions []string
+			err := bucket.ForEach(func(version, _ []byte) error {
+				versions = append(versions, string(version))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			sortVersions(versions)
+			for _, version := range versionsAfter(string(name), versions, after) {
+				if len(plugins) == limit {
+					break
+				}
+				plugin, err := decodePlugin(tx, string(name), version, bucket.Get([]byte(version)))
+				if err != nil {
+					return err
+				}
+				plugins = append(plugins, plugin)
+			}
+		}
+		total = int(pluginCount(tx))
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return plugins, total, nil
+}
+
+func (b *BoltStore) ListVersions(ctx context.Context, name string) ([]string, error) {
+	var versions []string
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(pluginsBucket).Bucket([]byte(name))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(version, _ []byte) error {
+			versions = append(versions, string(version))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortVersions(versions)
+	return versions, nil
+}
+
+func (b *BoltStore) DeletePlugin(ctx context.Context, name, version string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		versions := tx.Bucket(pluginsBucket).Bucket([]byte(name))
+		if versions == nil || versions.Get([]byte(version)) == nil {
+			return ErrNotFound
+		}
+		if err := versions.Delete([]byte(version)); err != nil {
+			return err
+		}
+		if err := putPluginCount(tx, pluginCount(tx)-1); err != nil {
+			return err
+		}
+		if err := tx.Bucket(downloadsBucket).Delete(versionRef(name, version)); err != nil {
+			return err
+		}
+		// Drop the per-name bucket once its last version is gone.
+		if k, _ := versions.Cursor().First(); k == nil {
+			return tx.Bucket(pluginsBucket).DeleteBucket([]byte(name))
+		}
+		return nil
+	})
+}
+
+func (b *BoltStore) CountDownload(ctx context.Context, name, version string) (int64, error) {
+	var downloads int64
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		versions := tx.Bucket(pluginsBucket).Bucket([]byte(name))
+		if versions == nil || versions.Get([]byte(version)) == nil {
+			return ErrNotFound
+		}
+		bucket := tx.Bucket(downloadsBucket)
+		downloads = getCounter(bucket, versionRef(name, version)) + 1
+		return putCounter(bucket, versionRef(name, version), downloads)
+	})
+	return downloads, err
+}
+
+func (b *BoltStore) GetNamespace(ctx context.Context, name string) (*pb.Namespace, error) {
+	var ns *pb.Namespace
+	err := b.db.View(func(tx *bolt.Tx) error {
+		var err error
+		ns, err = getNamespace(tx, name)
+		return err
+	})
+	return ns, err
+}
+
+func getNamespace(tx *bolt.Tx, name string) (*pb.Namespace, error) {
+	value := tx.Bucket(namespacesBucket).Get([]byte(name))
+	if value == nil {
+		return nil, ErrNotFound
+	}
+	var ns pb.Namespace
+	if err := json.Unmarshal(value, &ns); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal namespace %s: %w", name, err)
+	}
+	return &ns, nil
+}
+
+func putNamespace(tx *bolt.Tx, ns *pb.Namespace) error {
+	value, err := json.Marshal(ns)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(namespacesBucket).Put([]byte(ns.Name), value)
+}
+
+func (b *BoltStore) CreateNamespace(ctx context.Context, ns *pb.Namespace) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(namespacesBucket).Get([]byte(ns.Name)) != nil {
+			return ErrExists
+		}
+		return putNamespace(tx, ns)
+	})
+}
+
+func (b *BoltStore) UpdateNamespace(ctx context.Context, name string, fn func(*pb.Namespace) error) (*pb.Namespace, error) {
+	var ns *pb.Namespace
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		ns, err = getNamespace(tx, name)
+		if err != nil {
+			return err
+		}
+		if err := fn(ns); err != nil {
+			return err
+		}
+		return putNamespace(tx, ns)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
+
+func (b *BoltStore) UpdateNamespacePlugin(ctx context.Context, namespace, name, version string, fn func(*pb.Namespace, *pb.Plugin) error) (*pb.Plugin, error) {
+	var plugin *pb.Plugin
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		ns, err := getNamespace(tx, namespace)
+		if err != nil {
+			return err
+		}
+		plugin, err = getPlugin(tx, name, version)
+		if err != nil {
+			return err
+		}
+		if err := fn(ns, plugin); err != nil {
+			return err
+		}
+		if err := putNamespace(tx, ns); err != nil {
+			return err
+		}
+		return putPlugin(tx, plugin)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return plugin, nil
+}
+
+func (b *BoltStore) PutPinJob(ctx context.Context, job *PinJob) error {
+	value, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(pinJobsBucket).Put([]byte(job.ID), value)
+	})
+}
+
+func (b *BoltStore) ListPinJobs(ctx context.Context) ([]*PinJob, error) {
+	var jobs []*PinJob
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(pinJobsBucket).ForEach(func(id, value []byte) error {
+			var job PinJob
+			if err := json.Unmarshal(value, &job); err != nil {
+				return fmt.Errorf("failed to unmarshal pin job %s: %w", id, err)
+			}
+			jobs = append(jobs, &job)
+			return nil
+		})
+	})
+	return jobs, err
+}
+
+func (b *BoltStore) DeletePinJob(ctx context.Context, id string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(pinJobsBucket).Delete([]byte(id))
+	})
+}
+
+func (b *BoltStore) Ping(ctx context.Context) error {
+	return b.db.View(func(tx *bolt.Tx) error { return nil })
+}
+
+func (b *BoltStore) Close() error {
+	return b.db.Close()
+}
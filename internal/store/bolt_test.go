@@ -0,0 +1,37 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func openBolt(t *testing.T) *BoltStore {
+	t.Helper()
+	b, err := NewBoltStore(filepath.Join(t.TempDir(), "registry.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { b.Close() })
+	return b
+}
+
+func TestBoltPluginCountSurvivesReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "registry.db")
+	b, err := NewBoltStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	createPlugins(t, b, testPlugin("a", "1.0.0"), testPlugin("a", "1.1.0"))
+	b.Close()
+
+	b, err = NewBoltStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	if _, total, err := b.ListPluginsPage(ctx, PageCursor{}, 1); err != nil || total != 2 {
+		t.Fatalf("total = %d, %v, want 2", total, err)
+	}
+}
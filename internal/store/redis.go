@@ -0,0 +1,579 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"spacecore_registry/internal/metrics"
+	"spacecore_registry/pb"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore keeps each plugin version under a plugin:<name>:<version> key,
+// with its download count in a downloads:<name>:<version> counter, and
+// indexes the versions of each plugin in a versions:<name> set. cid:<cid> keys
+// point back at the plugin key registered for that content, and namespace:<ns>
+// holds namespace ownership. Pending pin jobs live in the pinjobs hash.
+//
+// For paginated listing, the plugin-names sorted set holds every name (all
+// scored 0, so ordered lexically) and plugin-keys holds every plugin key for
+// the total count.
+type RedisStore struct {
+	client *redis.Client
+
+	indexMu sync.Mutex
+	indexed bool
+}
+
+func NewRedisStore(addr string) *RedisStore {
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	client.AddHook(errorHook{})
+	return &RedisStore{client: client}
+}
+
+// errorHook counts failed commands. Missing keys and transactions that lost
+// a WATCH race are expected and not counted.
+type errorHook struct{}
+
+func (errorHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := next(ctx, network, addr)
+		if err != nil {
+			metrics.RedisErrors.WithLabelValues("dial").Inc()
+		}
+		return conn, err
+	}
+}
+
+func (errorHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		countRedisError(cmd)
+		return err
+	}
+}
+
+func (errorHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		for _, cmd := range cmds {
+			countRedisError(cmd)
+		}
+		return err
+	}
+}
+
+func countRedisError(cmd redis.Cmder) {
+	if err := cmd.Err(); err != nil && !errors.Is(err, redis.Nil) && !errors.Is(err, redis.TxFailedErr) {
+		metrics.RedisErrors.WithLabelValues(cmd.Name()).Inc()
+	}
+}
+
+func pluginKey(name, version string) string {
+	return fmt.Sprintf("plugin:%s:%s", name, version)
+}
+
+// downloadsKey returns the download counter of the plugin stored at key.
+func downloadsKey(key string) string {
+	return "downloads:" + strings.TrimPrefix(key, "plugin:")
+}
+
+func versionsKey(name string) string {
+	return fmt.Sprintf("versions:%s", name)
+}
+
+func cidKey(cid string) string {
+	return fmt.Sprintf("cid:%s", cid)
+}
+
+func namespaceKey(name string) string {
+	return fmt.Sprintf("namespace:%s", name)
+}
+
+const (
+	pinJobsKey     = "pinjobs"
+	pluginNamesKey = "plugin-names"
+	pluginKeysKey  = "plugin-keys"
+	// listIndexKey marks that the versions and listing sets have been
+	// backfilled from plugin keys written before they existed.
+	listIndexKey = "plugin-index:v2"
+)
+
+// scanBatch is the COUNT hint for SCAN and the MGET batch size.
+const scanBatch = 1000
+
+// maxTxRetries bounds optimistic transactions that lose a WATCH race.
+const maxTxRetries = 10
+
+func (r *RedisStore) CreatePlugin(ctx context.Context, plugin *pb.Plugin) error {
+	value, err := json.Marshal(plugin)
+	if err != nil {
+		return err
+	}
+	key := pluginKey(plugin.Name, plugin.Version)
+	txf := func(tx *redis.Tx) error {
+		n, err := tx.Exists(ctx, key).Result()
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return ErrExists
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, value, 0)
+			pipe.SAdd(ctx, versionsKey(plugin.Name), plugin.Version)
+			pipe.Set(ctx, cidKey(rootCID(plugin.Cid)), key, 0)
+			pipe.ZAdd(ctx, pluginNamesKey, redis.Z{Member: plugin.Name})
+			pipe.ZAdd(ctx, pluginKeysKey, redis.Z{Member: key})
+			return nil
+		})
+		return err
+	}
+	for i := 0; i < maxTxRetries; i++ {
+		err := r.client.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		return err
+	}
+	return fmt.Errorf("create of %s kept conflicting, giving up", key)
+}
+
+func (r *RedisStore) UpdatePlugin(ctx context.Context, name, version string, fn func(*pb.Plugin) error) (*pb.Plugin, error) {
+	key := pluginKey(name, version)
+	plugin, err := updateJSON(ctx, r.client, key, func(p *pb.Plugin) error {
+		if err := fn(p); err != nil {
+			return err
+		}
+		p.Downloads = 0
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	plugin.Downloads, err = r.client.Get(ctx, downloadsKey(key)).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	return plugin, nil
+}
+
+func (r *RedisStore) GetPlugin(ctx context.Context, name, version string) (*pb.Plugin, error) {
+	return r.getPluginKey(ctx, pluginKey(name, version))
+}
+
+func (r *RedisStore) getPluginKey(ctx context.Context, key string) (*pb.Plugin, error) {
+	plugins, err := r.getPlugins(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+	if len(plugins) == 0 {
+		return nil, ErrNotFound
+	}
+	return plugins[0], nil
+}
+
+func (r *RedisStore) IndexCID(ctx context.Context, cid, name, version string) error {
+	return r.client.Set(ctx, cidKey(cid), pluginKey(name, version), 0).Err()
+}
+
+func (r *RedisStore) GetPluginByCID(ctx context.Context, cid string) (*pb.Plugin, error) {
+	key, err := r.client.Get(ctx, cidKey(cid)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.getPluginKey(ctx, key)
+}
+
+// ListPlugins walks the plugin keys with SCAN, so it never blocks Redis the
+// way KEYS does.
+func (r *RedisStore) ListPlugins(ctx context.Context) ([]*pb.Plugin, error) {
+	var plugins []*pb.Plugin
+	iter := r.client.Scan(ctx, 0, "plugin:*", scanBatch).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == scanBatch {
+			batch, err := r.getPlugins(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+			plugins = append(plugins, batch...)
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	batch, err := r.getPlugins(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	return append(plugins, batch...), nil
+}
+
+// getPlugins loads plugin keys and their download counters with MGET,
+// skipping keys that no longer exist.
+func (r *RedisStore) getPlugins(ctx context.Context, keys []string) ([]*pb.Plugin, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	all := make([]string, 0, 2*len(keys))
+	all = append(all, keys...)
+	for _, key := range keys {
+		all = append(all, downloadsKey(key))
+	}
+	values, err := r.client.MGet(ctx, all...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	plugins := make([]*pb.Plugin, 0, len(keys))
+	for i, key := range keys {
+		// The key may have been deleted since it was listed.
+		s, ok := values[i].(string)
+		if !ok {
+			continue
+		}
+		var plugin pb.Plugin
+		if err := json.Unmarshal([]byte(s), &plugin); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", key, err)
+		}
+		plugin.Downloads = 0
+		if downloads, ok := values[len(keys)+i].(string); ok {
+			plugin.Downloads, _ = strconv.ParseInt(downloads, 10, 64)
+		}
+		plugins = append(plugins, &plugin)
+	}
+	return plugins, nil
+}
+
+func (r *RedisStore) ListPluginsPage(ctx context.Context, after PageCursor, limit int) ([]*pb.Plugin, int, error) {
+	if err := r.ensureIndex(ctx); err != nil {
+		return nil, 0, err
+	}
+	total, err := r.client.ZCard(ctx, pluginKeysKey).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	from := "-"
+	if after.Name != "" {
+		from = "[" + after.Name
+	}
+	var keys []string
+	for len(keys) < limit {
+		names, err := r.client.ZRangeByLex(ctx, pluginNamesKey, &redis.ZRangeBy{
+			Min:   from,
+			Max:   "+",
+			Count: int64(limit),
+		}).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, name := range names {
+			versions, err := r.ListVersions(ctx, name)
+			if err != nil {
+				return nil, 0, err
+			}
+			for _, version := range versionsAfter(name, versions, after) {
+				if len(keys) == limit {
+					break
+				}
+				keys = append(keys, pluginKey(name, version))
+			}
+			if len(keys) == limit {
+				break
+			}
+		}
+		if len(names) < limit {
+			break
+		}
+		from = "(" + names[len(names)-1]
+	}
+	plugins, err := r.getPlugins(ctx, keys)
+	if err != nil {
+		return nil, 0, err
+	}
+	return plugins, int(total), nil
+}
+
+// ensureIndex backfills the versions and listing sets from existing plugin
+// keys the first time they are needed, so versions registered before the sets
+// existed can still be resolved and listed. It uses SCAN and is safe to
+// repeat.
+func (r *RedisStore) ensureIndex(ctx context.Context) error {
+	r.indexMu.Lock()
+	defer r.indexMu.Unlock()
+	if r.indexed {
+		return nil
+	}
+	n, err := r.client.Exists(ctx, listIndexKey).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		iter := r.client.Scan(ctx, 0, "plugin:*", scanBatch).Iterator()
+		for iter.Next(ctx) {
+			key := iter.Val()
+			name, version, ok := strings.Cut(strings.TrimPrefix(key, "plugin:"), ":")
+			if !ok {
+				continue
+			}
+			_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.SAdd(ctx, versionsKey(name), version)
+				pipe.ZAdd(ctx, pluginNamesKey, redis.Z{Member: name})
+				pipe.ZAdd(ctx, pluginKeysKey, redis.Z{Member: key})
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+		if err := r.client.Set(ctx, listIndexKey, 1, 0).Err(); err != nil {
+			return err
+		}
+	}
+	r.indexed = true
+	return nil
+}
+
+func (r *RedisStore) ListVersions(ctx context.Context, name string) ([]string, error) {
+	if err := r.ensureIndex(ctx); err != nil {
+		return nil, err
+	}
+	versions, err := r.client.SMembers(ctx, versionsKey(name)).Result()
+	if err != nil {
+		return nil, err
+	}
+	sortVersions(versions)
+	return versions, nil
+}
+
+// DeletePlugin watches the versions set as well as the plugin key, so a
+// concurrent CreatePlugin for the same name retries the delete instead of
+// having the name dropped from the listing underneath it.
+func (r *RedisStore) DeletePlugin(ctx context.Context, name, version string) error {
+	key := pluginKey(name, version)
+	txf := func(tx *redis.Tx) error {
+		n, err := tx.Exists(ctx, key).Result()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrNotFound
+		}
+		versions, err := tx.SMembers(ctx, versionsKey(name)).Result()
+		if err != nil {
+			return err
+		}
+		last := len(versions) == 0 || len(versions) == 1 && versions[0] == version
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, key, downloadsKey(key))
+			pipe.SRem(ctx, versionsKey(name), version)
+			pipe.ZRem(ctx, pluginKeysKey, key)
+			if last {
+				pipe.ZRem(ctx, pluginNamesKey, name)
+			}
+			return nil
+		})
+		return err
+	}
+	for i := 0; i < maxTxRetries; i++ {
+		err := r.client.Watch(ctx, txf, key, versionsKey(name))
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		return err
+	}
+	return fmt.Errorf("delete of %s kept conflicting, giving up", key)
+}
+
+func (r *RedisStore) CountDownload(ctx context.Context, name, version string) (int64, error) {
+	return r.client.Incr(ctx, downloadsKey(pluginKey(name, version))).Result()
+}
+
+func (r *RedisStore) GetNamespace(ctx context.Context, name string) (*pb.Namespace, error) {
+	value, err := r.client.Get(ctx, namespaceKey(name)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ns pb.Namespace
+	if err := json.Unmarshal(value, &ns); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal namespace %s: %w", name, err)
+	}
+	return &ns, nil
+}
+
+func (r *RedisStore) CreateNamespace(ctx context.Context, ns *pb.Namespace) error {
+	value, err := json.Marshal(ns)
+	if err != nil {
+		return err
+	}
+	ok, err := r.client.SetNX(ctx, namespaceKey(ns.Name), value, 0).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrExists
+	}
+	return nil
+}
+
+func (r *RedisStore) UpdateNamespace(ctx context.Context, name string, fn func(*pb.Namespace) error) (*pb.Namespace, error) {
+	return updateJSON(ctx, r.client, namespaceKey(name), fn)
+}
+
+func (r *RedisStore) UpdateNamespacePlugin(ctx context.Context, namespace, name, version string, fn func(*pb.Namespace, *pb.Plugin) error) (*pb.Plugin, error) {
+	nsKey, key := namespaceKey(namespace), pluginKey(name, version)
+	var plugin *pb.Plugin
+	txf := func(tx *redis.Tx) error {
+		ns, err := getJSON[pb.Namespace](ctx, tx, nsKey)
+		if err != nil {
+			return err
+		}
+		p, err := getJSON[pb.Plugin](ctx, tx, key)
+		if err != nil {
+			return err
+		}
+		if err := fn(ns, p); err != nil {
+			return err
+		}
+		p.Downloads = 0
+		nsValue, err := json.Marshal(ns)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, nsKey, nsValue, 0)
+			pipe.Set(ctx, key, value, 0)
+			return nil
+		})
+		plugin = p
+		return err
+	}
+	for i := 0; i < maxTxRetries; i++ {
+		err := r.client.Watch(ctx, txf, nsKey, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		plugin.Downloads, err = r.client.Get(ctx, downloadsKey(key)).Int64()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, err
+		}
+		return plugin, nil
+	}
+	return nil, fmt.Errorf("update of %s and %s kept conflicting, giving up", nsKey, key)
+}
+
+// getJSON reads the JSON value at key.
+func getJSON[T any](ctx context.Context, tx *redis.Tx, key string) (*T, error) {
+	value, err := tx.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	v := new(T)
+	if err := json.Unmarshal(value, v); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", key, err)
+	}
+	return v, nil
+}
+
+// updateJSON runs a read-modify-write of the JSON value at key under WATCH,
+// retrying when another client changes the key concurrently.
+func updateJSON[T any](ctx context.Context, client *redis.Client, key string, fn func(*T) error) (*T, error) {
+	var updated *T
+	txf := func(tx *redis.Tx) error {
+		v, err := getJSON[T](ctx, tx, key)
+		if err != nil {
+			return err
+		}
+		if err := fn(v); err != nil {
+			return err
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, value, 0)
+			return nil
+		})
+		updated = v
+		return err
+	}
+
+	for i := 0; i < maxTxRetries; i++ {
+		err := client.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return updated, nil
+	}
+	return nil, fmt.Errorf("update of %s kept conflicting, giving up", key)
+}
+
+func (r *RedisStore) PutPinJob(ctx context.Context, job *PinJob) error {
+	value, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return r.client.HSet(ctx, pinJobsKey, job.ID, value).Err()
+}
+
+func (r *RedisStore) ListPinJobs(ctx context.Context) ([]*PinJob, error) {
+	values, err := r.client.HGetAll(ctx, pinJobsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]*PinJob, 0, len(values))
+	for id, value := range values {
+		var job PinJob
+		if err := json.Unmarshal([]byte(value), &job); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal pin job %s: %w", id, err)
+		}
+		jobs = append(jobs, &job)
+	}
+	return jobs, nil
+}
+
+func (r *RedisStore) DeletePinJob(ctx context.Context, id string) error {
+	return r.client.HDel(ctx, pinJobsKey, id).Err()
+}
+
+func (r *RedisStore) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
+func (r *RedisStore) Close() error {
+	return r.client.Close()
+}
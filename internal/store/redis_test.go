@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestRedisBackfillsListIndex(t *testing.T) {
+	ctx := context.Background()
+	r := openRedis(t)
+	// Plugins written before the versions and listing sets existed.
+	for _, p := range []struct{ name, version string }{{"a", "1.10.0"}, {"a", "1.2.0"}, {"b", "0.1.0"}} {
+		value, err := json.Marshal(testPlugin(p.name, p.version))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := r.client.Set(ctx, pluginKey(p.name, p.version), value, 0).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	versions, err := r.ListVersions(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1.2.0", "1.10.0"}; !slices.Equal(versions, want) {
+		t.Errorf("ListVersions = %v, want %v", versions, want)
+	}
+	plugins, total, err := r.ListPluginsPage(ctx, PageCursor{Name: "a", Version: "1.10.0"}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(plugins) != 1 || plugins[0].Name != "b" {
+		t.Errorf("ListPluginsPage = %d plugins of %d, want b of 3", len(plugins), total)
+	}
+
+}
@@ -0,0 +1,189 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"spacecore_registry/pb"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var (
+	// ErrNotFound is returned when the requested record does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrExists is returned when creating a record that already exists.
+	ErrExists = errors.New("already exists")
+)
+
+// MetadataStore persists plugin metadata and namespace ownership. Plugin
+// content itself lives in IPFS; the store only maps name and version to the
+// registered record.
+type MetadataStore interface {
+	// CreatePlugin records a new plugin version. Published versions are
+	// immutable, so it fails with ErrExists if the version is already stored.
+	CreatePlugin(ctx context.Context, plugin *pb.Plugin) error
+	// UpdatePlugin applies fn to a stored plugin version atomically. fn must
+	// not change the name, version or CID.
+	UpdatePlugin(ctx context.Context, name, version string, fn func(*pb.Plugin) error) (*pb.Plugin, error)
+	GetPlugin(ctx context.Context, name, version string) (*pb.Plugin, error)
+	// GetPluginByCID returns the plugin most recently registered with the
+	// given bare root CID.
+	GetPluginByCID(ctx context.Context, cid string) (*pb.Plugin, error)
+	// IndexCID makes an additional artifact CID of a stored plugin version
+	// resolvable through GetPluginByCID.
+	IndexCID(ctx context.Context, cid, name, version string) error
+	// ListPlugins returns every plugin version in no particular order. It
+	// walks the whole store, so it is meant for background jobs; listings
+	// served to clients use ListPluginsPage.
+	ListPlugins(ctx context.Context) ([]*pb.Plugin, error)
+	// ListPluginsPage returns up to limit plugin versions ordered by name,
+	// then semver, starting after the version identified by after. The zero
+	// PageCursor starts at the beginning. It also returns the total number
+	// of plugin versions stored.
+	ListPluginsPage(ctx context.Context, after PageCursor, limit int) ([]*pb.Plugin, int, error)
+	// ListVersions returns every registered version of name in ascending
+	// semver order.
+	ListVersions(ctx context.Context, name string) ([]string, error)
+	DeletePlugin(ctx context.Context, name, version string) error
+	// CountDownload adds a completed download of a plugin version and
+	// returns the new total. Counts are kept apart from the version record,
+	// so they never contend with its updates; reads fill in Downloads.
+	CountDownload(ctx context.Context, name, version string) (int64, error)
+
+	GetNamespace(ctx context.Context, name string) (*pb.Namespace, error)
+	// CreateNamespace claims an unowned namespace, failing with ErrExists if
+	// someone already holds it.
+	CreateNamespace(ctx context.Context, ns *pb.Namespace) error
+	// UpdateNamespace applies fn to the stored namespace atomically and
+	// returns the result. An error from fn aborts the update.
+	UpdateNamespace(ctx context.Context, name string, fn func(*pb.Namespace) error) (*pb.Namespace, error)
+	// UpdateNamespacePlugin applies fn to a namespace and a plugin version
+	// in one atomic update, so an admin request that changes the plugin
+	// consumes the namespace's request sequence only if the change is
+	// stored. fn must not change the name, version or CID of the plugin.
+	UpdateNamespacePlugin(ctx context.Context, namespace, name, version string, fn func(*pb.Namespace, *pb.Plugin) error) (*pb.Plugin, error)
+
+	// PutPinJob creates or replaces a remote pinning job. Jobs are persisted
+	// so pending pins survive a restart.
+	PutPinJob(ctx context.Context, job *PinJob) error
+	ListPinJobs(ctx context.Context) ([]*PinJob, error)
+	DeletePinJob(ctx context.Context, id string) error
+
+	// Ping checks that the store can be reached.
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// PageCursor identifies the last plugin version of a page.
+type PageCursor struct {
+	Name    string
+	Version string
+}
+
+// versionsAfter returns the versions of name, in ascending semver order, that
+// sort after cursor.
+func versionsAfter(name string, versions []string, cursor PageCursor) []string {
+	if name != cursor.Name {
+		return versions
+	}
+	for i, version := range versions {
+		if VersionLess(cursor.Version, version) {
+			return versions[i:]
+		}
+	}
+	return nil
+}
+
+// PinJob is a pending request to pin a plugin version on one provider.
+type PinJob struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	CID      string `json:"cid"`
+	Provider string `json:"provider"`
+	// Artifact is the root CID of the platform variant being pinned, empty
+	// for the plugin's own CID.
+	Artifact string `json:"artifact,omitempty"`
+	Attempts int    `json:"attempts"`
+	// NextAttempt is when the job becomes due again after a failure, or
+	// when the provider's status is next checked.
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+	// AcceptedAt is when the provider accepted the pin request. From then
+	// on the job polls the provider's status instead of pinning again.
+	AcceptedAt time.Time `json:"accepted_at"`
+	// Polls counts the status checks since the request was accepted.
+	Polls int `json:"polls,omitempty"`
+	// RequestID is the provider's ID for the accepted request, if it
+	// returned one.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// PinJobID identifies the job for an artifact of a plugin version on a
+// provider, so re-enqueueing the same pin replaces rather than duplicates it.
+func PinJobID(name, version, artifact, provider string) string {
+	if artifact == "" {
+		return fmt.Sprintf("%s@%s/%s", name, version, provider)
+	}
+	return fmt.Sprintf("%s@%s/%s/%s", name, version, provider, artifact)
+}
+
+// Backend names accepted by Open.
+const (
+	BackendRedis = "redis"
+	BackendBolt  = "bolt"
+)
+
+// Options configures the backend selected by Open.
+type Options struct {
+	Backend   string
+	RedisAddr string
+	BoltPath  string
+}
+
+// Open returns the MetadataStore selected by opts.Backend. An empty backend
+// defaults to Redis.
+func Open(opts Options) (MetadataStore, error) {
+	switch opts.Backend {
+	case "", BackendRedis:
+		return NewRedisStore(opts.RedisAddr), nil
+	case BackendBolt:
+		return NewBoltStore(opts.BoltPath)
+	default:
+		return nil, fmt.Errorf("unknown metadata store backend %q", opts.Backend)
+	}
+}
+
+// sortVersions orders versions by semver precedence. Entries that do not parse
+// as semver sort first, lexically, so legacy records stay visible.
+func sortVersions(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return VersionLess(versions[i], versions[j])
+	})
+}
+
+// VersionLess reports whether version a sorts before b in the order stores
+// list them.
+func VersionLess(a, b string) bool {
+	va, erra := semver.NewVersion(a)
+	vb, errb := semver.NewVersion(b)
+	switch {
+	case erra != nil && errb != nil:
+		return a < b
+	case erra != nil:
+		return true
+	case errb != nil:
+		return false
+	}
+	return va.LessThan(vb)
+}
+
+// rootCID strips the /ipfs/ prefix that plugin records carry on their CID.
+func rootCID(cid string) string {
+	return strings.TrimPrefix(cid, "/ipfs/")
+}
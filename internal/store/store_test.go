@@ -0,0 +1,329 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"spacecore_registry/pb"
+)
+
+// forEachStore runs fn against a fresh instance of every backend. Redis is
+// only covered when REDIS_TEST_ADDR points at a scratch server, which the
+// tests flush.
+func forEachStore(t *testing.T, fn func(t *testing.T, s MetadataStore)) {
+	t.Run("bolt", func(t *testing.T) {
+		fn(t, openBolt(t))
+	})
+	t.Run("redis", func(t *testing.T) {
+		fn(t, openRedis(t))
+	})
+}
+
+func openRedis(t *testing.T) *RedisStore {
+	t.Helper()
+	addr := os.Getenv("REDIS_TEST_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_TEST_ADDR not set")
+	}
+	r := NewRedisStore(addr)
+	flush := func() {
+		if err := r.client.FlushDB(context.Background()).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	flush()
+	t.Cleanup(func() {
+		flush()
+		r.Close()
+	})
+	return r
+}
+
+func testPlugin(name, version string) *pb.Plugin {
+	return &pb.Plugin{Name: name, Version: version, Cid: "/ipfs/cid-" + name + "-" + version}
+}
+
+func createPlugins(t *testing.T, s MetadataStore, plugins ...*pb.Plugin) {
+	t.Helper()
+	for _, p := range plugins {
+		if err := s.CreatePlugin(context.Background(), p); err != nil {
+			t.Fatalf("CreatePlugin(%s:%s): %v", p.Name, p.Version, err)
+		}
+	}
+}
+
+func TestPlugins(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s MetadataStore) {
+		ctx := context.Background()
+		createPlugins(t, s, testPlugin("a", "1.0.0"), testPlugin("a", "1.10.0"), testPlugin("a", "1.2.0"), testPlugin("b", "0.1.0"))
+
+		tests := []struct {
+			name    string
+			run     func() error
+			wantErr error
+		}{
+			{"create existing", func() error { return s.CreatePlugin(ctx, testPlugin("a", "1.0.0")) }, ErrExists},
+			{"get missing version", func() error { _, err := s.GetPlugin(ctx, "a", "9.9.9"); return err }, ErrNotFound},
+			{"get missing name", func() error { _, err := s.GetPlugin(ctx, "c", "1.0.0"); return err }, ErrNotFound},
+			{"get by missing cid", func() error { _, err := s.GetPluginByCID(ctx, "nope"); return err }, ErrNotFound},
+			{"update missing", func() error {
+				_, err := s.UpdatePlugin(ctx, "c", "1.0.0", func(*pb.Plugin) error { return nil })
+				return err
+			}, ErrNotFound},
+			{"delete missing", func() error { return s.DeletePlugin(ctx, "a", "9.9.9") }, ErrNotFound},
+			{"count download of missing", func() error { _, err := s.CountDownload(ctx, "c", "1.0.0"); return err }, ErrNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if err := tt.run(); !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got %v, want %v", err, tt.wantErr)
+				}
+			})
+		}
+
+		got, err := s.GetPluginByCID(ctx, "cid-a-1.2.0")
+		if err != nil || got.Version != "1.2.0" {
+			t.Fatalf("GetPluginByCID = %v, %v", got, err)
+		}
+		if err := s.IndexCID(ctx, "artifact", "b", "0.1.0"); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := s.GetPluginByCID(ctx, "artifact"); err != nil || got.Name != "b" {
+			t.Fatalf("GetPluginByCID(artifact) = %v, %v", got, err)
+		}
+
+		versions, err := s.ListVersions(ctx, "a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"1.0.0", "1.2.0", "1.10.0"}; !slices.Equal(versions, want) {
+			t.Fatalf("ListVersions = %v, want %v", versions, want)
+		}
+
+		updated, err := s.UpdatePlugin(ctx, "a", "1.0.0", func(p *pb.Plugin) error {
+			p.Yanked = true
+			return nil
+		})
+		if err != nil || !updated.Yanked {
+			t.Fatalf("UpdatePlugin = %v, %v", updated, err)
+		}
+		if got, _ := s.GetPlugin(ctx, "a", "1.0.0"); !got.Yanked {
+			t.Fatal("update was not stored")
+		}
+
+		for want := int64(1); want <= 2; want++ {
+			if n, err := s.CountDownload(ctx, "a", "1.2.0"); err != nil || n != want {
+				t.Fatalf("CountDownload = %d, %v, want %d", n, err, want)
+			}
+		}
+		if got, _ := s.GetPlugin(ctx, "a", "1.2.0"); got.Downloads != 2 {
+			t.Fatalf("Downloads = %d, want 2", got.Downloads)
+		}
+		// Updates must not overwrite the separate counter.
+		if _, err := s.UpdatePlugin(ctx, "a", "1.2.0", func(p *pb.Plugin) error { p.Downloads = 99; return nil }); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := s.GetPlugin(ctx, "a", "1.2.0"); got.Downloads != 2 {
+			t.Fatalf("Downloads after update = %d, want 2", got.Downloads)
+		}
+
+		all, err := s.ListPlugins(ctx)
+		if err != nil || len(all) != 4 {
+			t.Fatalf("ListPlugins = %d plugins, %v", len(all), err)
+		}
+
+		if err := s.DeletePlugin(ctx, "b", "0.1.0"); err != nil {
+			t.Fatal(err)
+		}
+		if versions, _ := s.ListVersions(ctx, "b"); len(versions) != 0 {
+			t.Fatalf("versions left after delete: %v", versions)
+		}
+		if _, total, _ := s.ListPluginsPage(ctx, PageCursor{}, 10); total != 3 {
+			t.Fatalf("total after delete = %d, want 3", total)
+		}
+	})
+}
+
+func TestListPluginsPage(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s MetadataStore) {
+		ctx := context.Background()
+		createPlugins(t, s,
+			testPlugin("b", "2.0.0"), testPlugin("a", "1.10.0"), testPlugin("a", "1.2.0"),
+			testPlugin("c", "0.1.0"), testPlugin("a", "1.2.0-beta.1"),
+		)
+		all := []string{"a@1.2.0-beta.1", "a@1.2.0", "a@1.10.0", "b@2.0.0", "c@0.1.0"}
+
+		tests := []struct {
+			name  string
+			after PageCursor
+			limit int
+			want  []string
+		}{
+			{"first page", PageCursor{}, 2, all[:2]},
+			{"within a name", PageCursor{Name: "a", Version: "1.2.0"}, 2, all[2:4]},
+			{"across names", PageCursor{Name: "a", Version: "1.10.0"}, 10, all[3:]},
+			{"last page", PageCursor{Name: "b", Version: "2.0.0"}, 2, all[4:]},
+			{"past the end", PageCursor{Name: "c", Version: "0.1.0"}, 2, nil},
+			{"everything", PageCursor{}, 10, all},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				plugins, total, err := s.ListPluginsPage(ctx, tt.after, tt.limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if total != len(all) {
+					t.Errorf("total = %d, want %d", total, len(all))
+				}
+				var got []string
+				for _, p := range plugins {
+					got = append(got, p.Name+"@"+p.Version)
+				}
+				if !slices.Equal(got, tt.want) {
+					t.Errorf("page = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+}
+
+func TestNamespaces(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s MetadataStore) {
+		ctx := context.Background()
+		if _, err := s.GetNamespace(ctx, "ns"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("GetNamespace = %v, want ErrNotFound", err)
+		}
+		if err := s.CreateNamespace(ctx, &pb.Namespace{Name: "ns", Owner: "alice"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateNamespace(ctx, &pb.Namespace{Name: "ns", Owner: "bob"}); !errors.Is(err, ErrExists) {
+			t.Fatalf("second CreateNamespace = %v, want ErrExists", err)
+		}
+
+		abort := errors.New("abort")
+		if _, err := s.UpdateNamespace(ctx, "ns", func(ns *pb.Namespace) error {
+			ns.Owner = "mallory"
+			return abort
+		}); !errors.Is(err, abort) {
+			t.Fatalf("UpdateNamespace = %v, want %v", err, abort)
+		}
+		ns, err := s.UpdateNamespace(ctx, "ns", func(ns *pb.Namespace) error {
+			ns.Maintainers = append(ns.Maintainers, "bob")
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := s.GetNamespace(ctx, "ns")
+		if err != nil || got.Owner != "alice" || !slices.Equal(got.Maintainers, ns.Maintainers) {
+			t.Fatalf("GetNamespace = %v, %v", got, err)
+		}
+	})
+}
+
+func TestPinJobs(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s MetadataStore) {
+		ctx := context.Background()
+		next := time.Unix(1700000000, 0).UTC()
+		jobs := []*PinJob{
+			{ID: PinJobID("a", "1.0.0", "", "pinata"), Name: "a", Version: "1.0.0", CID: "c1", Provider: "pinata", NextAttempt: next},
+			{ID: PinJobID("a", "1.0.0", "c2", "pinata"), Name: "a", Version: "1.0.0", CID: "c2", Provider: "pinata", Artifact: "c2", NextAttempt: next},
+		}
+		for _, job := range jobs {
+			if err := s.PutPinJob(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// Putting a job again replaces it.
+		jobs[0].Attempts = 3
+		if err := s.PutPinJob(ctx, jobs[0]); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := s.ListPinJobs(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
+		if len(got) != 2 || got[0].Attempts != 3 || got[1].Artifact != "c2" || !got[0].NextAttempt.Equal(next) {
+			t.Fatalf("ListPinJobs = %+v", got)
+		}
+
+		if err := s.DeletePinJob(ctx, jobs[0].ID); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := s.ListPinJobs(ctx); len(got) != 1 || got[0].ID != jobs[1].ID {
+			t.Fatalf("ListPinJobs after delete = %+v", got)
+		}
+	})
+}
+
+func TestPinJobID(t *testing.T) {
+	tests := []struct {
+		artifact string
+		want     string
+	}{
+		{"", "ns/a@1.0.0/pinata"},
+		{"bafy", "ns/a@1.0.0/pinata/bafy"},
+	}
+	for _, tt := range tests {
+		if got := PinJobID("ns/a", "1.0.0", tt.artifact, "pinata"); got != tt.want {
+			t.Errorf("PinJobID(artifact %q) = %q, want %q", tt.artifact, got, tt.want)
+		}
+	}
+}
+
+func TestSortVersions(t *testing.T) {
+	versions := []string{"1.10.0", "legacy", "1.2.0", "1.2.0-rc.1", "0.9.0", "1.0"}
+	sortVersions(versions)
+	want := []string{"legacy", "0.9.0", "1.0", "1.2.0-rc.1", "1.2.0", "1.10.0"}
+	if !slices.Equal(versions, want) {
+		t.Fatalf("sortVersions = %v, want %v", versions, want)
+	}
+}
+
+func TestUpdateNamespacePlugin(t *testing.T) {
+	forEachStore(t, func(t *testing.T, s MetadataStore) {
+		ctx := context.Background()
+		createPlugins(t, s, testPlugin("ns/a", "1.0.0"))
+		if err := s.CreateNamespace(ctx, &pb.Namespace{Name: "ns", Owner: "alice"}); err != nil {
+			t.Fatal(err)
+		}
+
+		abort := errors.New("abort")
+		tests := []struct {
+			name    string
+			ns      string
+			version string
+			fail    error
+			wantErr error
+		}{
+			{"missing namespace", "other", "1.0.0", nil, ErrNotFound},
+			{"missing version", "ns", "2.0.0", nil, ErrNotFound},
+			{"aborted", "ns", "1.0.0", abort, abort},
+			{"applied", "ns", "1.0.0", nil, nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := s.UpdateNamespacePlugin(ctx, tt.ns, "ns/a", tt.version, func(ns *pb.Namespace, p *pb.Plugin) error {
+					ns.AdminSequence++
+					p.Yanked = true
+					return tt.fail
+				})
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got %v, want %v", err, tt.wantErr)
+				}
+				ns, _ := s.GetNamespace(ctx, "ns")
+				p, _ := s.GetPlugin(ctx, "ns/a", "1.0.0")
+				if applied := tt.wantErr == nil; (ns.AdminSequence == 1) != applied || p.Yanked != applied {
+					t.Errorf("sequence %d, yanked %v, want applied %v", ns.AdminSequence, p.Yanked, applied)
+				}
+			})
+		}
+	})
+}
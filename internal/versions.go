@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"spacecore_registry/pb"
+
+	"github.com/Masterminds/semver/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// latestVersion is the version spec that resolves to the newest stable release.
+const latestVersion = "latest"
+
+// normalizeVersion validates a version on register and returns its canonical
+// semver form, so "v1.2" and "1.2.0" are stored as the same version.
+func normalizeVersion(version string) (string, error) {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "version %q is not valid semver: %v", version, err)
+	}
+	return v.String(), nil
+}
+
+// resolvePlugin turns a version spec into a registered version of name and
+// returns its record. The spec may be empty or "latest", an exact version, or
+// a range such as "^1.2", "~1.4.0" or ">=2, <3". Yanked versions are only
+// returned when asked for exactly.
+//
+// Versions registered before they were normalized keep the string they were
+// stored under: "1.0" is found by "1.0", "1.0.0" and ranges alike, and a
+// version that isn't semver at all only by its exact string.
+func (s *pluginRegistryServer) resolvePlugin(ctx context.Context, name, spec string) (*pb.Plugin, error) {
+	versions, err := s.store.ListVersions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, status.Errorf(codes.NotFound, "plugin %s not found", name)
+	}
+	candidates, err := matchVersions(versions, spec)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid version %q: %v", spec, err)
+	}
+	exact := isExactVersion(spec) || slices.Contains(versions, spec)
+	for _, version := range candidates {
+		plugin, err := s.store.GetPlugin(ctx, name, version)
+		if err != nil {
+			return nil, storeError(err)
+		}
+		if exact || !plugin.Yanked {
+			return plugin, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "no version of %s matches %q (available: %s)", name, spec, strings.Join(versions, ", "))
+}
+
+func isExactVersion(spec string) bool {
+	_, err := semver.StrictNewVersion(strings.TrimPrefix(strings.TrimSpace(spec), "v"))
+	return err == nil
+}
+
+// matchVersions returns the entries of versions, which must be in ascending
+// semver order, that satisfy spec, highest first. Pre-releases are only
+// matched by an exact version or by a range that itself names a pre-release,
+// following semver precedence rules.
+func matchVersions(versions []string, spec string) ([]string, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" || spec == latestVersion {
+		spec = "*"
+	}
+	if slices.Contains(versions, spec) {
+		return []string{spec}, nil
+	}
+
+	if v, err := semver.StrictNewVersion(strings.TrimPrefix(spec, "v")); err == nil {
+		// Compare parsed versions so legacy entries such as "1.0" match.
+		for i := len(versions) - 1; i >= 0; i-- {
+			if candidate, err := semver.NewVersion(versions[i]); err == nil && candidate.Equal(v) {
+				return []string{versions[i]}, nil
+			}
+		}
+		return nil, nil
+	}
+
+	constraint, err := semver.NewConstraint(spec)
+	if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for i := len(versions) - 1; i >= 0; i-- {
+		v, err := semver.NewVersion(versions[i])
+		if err != nil {
+			continue
+		}
+		if constraint.Check(v) {
+			matches = append(matches, versions[i])
+		}
+	}
+	return matches, nil
+}
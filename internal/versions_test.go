@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"spacecore_registry/internal/index"
+	"spacecore_registry/internal/search"
+	"spacecore_registry/internal/store"
+	"spacecore_registry/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestServer returns a server backed by a fresh bolt store holding plugins.
+func newTestServer(t *testing.T, plugins ...*pb.Plugin) *pluginRegistryServer {
+	t.Helper()
+	b, err := store.NewBoltStore(filepath.Join(t.TempDir(), "registry.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { b.Close() })
+	for _, p := range plugins {
+		if err := b.CreatePlugin(context.Background(), p); err != nil {
+			t.Fatalf("CreatePlugin(%s:%s): %v", p.Name, p.Version, err)
+		}
+	}
+	return &pluginRegistryServer{store: b, index: index.NewPublisher(b, nil, nil, nil), search: search.NewIndex()}
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+		wantErr bool
+	}{
+		{"1.2.3", "1.2.3", false},
+		{"v1.2", "1.2.0", false},
+		{"1", "1.0.0", false},
+		{"1.2.3-rc.1+build.5", "1.2.3-rc.1+build.5", false},
+		{"latest", "", true},
+		{"1.2.3.4", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := normalizeVersion(tt.version)
+		if tt.wantErr {
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("normalizeVersion(%q) error = %v, want InvalidArgument", tt.version, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("normalizeVersion(%q) = %q, %v, want %q", tt.version, got, err, tt.want)
+		}
+	}
+}
+
+func TestMatchVersions(t *testing.T) {
+	versions := []string{"1.0.0", "1.2.0", "1.2.1", "1.3.0-beta.1", "2.0.0", "2.1.0", "3.0.0-rc.1"}
+	tests := []struct {
+		spec    string
+		want    []string
+		wantErr bool
+	}{
+		{"", []string{"2.1.0", "2.0.0", "1.2.1", "1.2.0", "1.0.0"}, false},
+		{"latest", []string{"2.1.0", "2.0.0", "1.2.1", "1.2.0", "1.0.0"}, false},
+		{"1.2.0", []string{"1.2.0"}, false},
+		{"v1.2.0", []string{"1.2.0"}, false},
+		{"9.9.9", nil, false},
+		{"^1.2", []string{"1.2.1", "1.2.0"}, false},
+		{"~1.2.0", []string{"1.2.1", "1.2.0"}, false},
+		{">=2, <3", []string{"2.1.0", "2.0.0"}, false},
+		{"3.0.0-rc.1", []string{"3.0.0-rc.1"}, false},
+		{"^1.3.0-beta.1", []string{"1.3.0-beta.1"}, false},
+		{">=3.0.0-rc.0", []string{"3.0.0-rc.1"}, false},
+		{"not a range!", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := matchVersions(versions, tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("matchVersions(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchLegacyVersions(t *testing.T) {
+	versions := []string{"legacy", "0.9", "1.0"}
+	tests := []struct {
+		spec string
+		want []string
+	}{
+		{"1.0", []string{"1.0"}},
+		{"1.0.0", []string{"1.0"}},
+		{"^0.9", []string{"0.9"}},
+		{"legacy", []string{"legacy"}},
+		{"", []string{"1.0", "0.9"}},
+	}
+	for _, tt := range tests {
+		got, err := matchVersions(versions, tt.spec)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("matchVersions(%q) = %v, %v, want %v", tt.spec, got, err, tt.want)
+		}
+	}
+}
+
+func TestResolvePlugin(t *testing.T) {
+	yanked := &pb.Plugin{Name: "a", Version: "1.1.0", Cid: "/ipfs/a-1.1.0", Yanked: true}
+	s := newTestServer(t,
+		&pb.Plugin{Name: "a", Version: "0.9", Cid: "/ipfs/a-0.9"},
+		&pb.Plugin{Name: "a", Version: "1.0.0", Cid: "/ipfs/a-1.0.0"},
+		yanked,
+		&pb.Plugin{Name: "a", Version: "1.2.0-beta.1", Cid: "/ipfs/a-1.2.0-beta.1"},
+		&pb.Plugin{Name: "a", Version: "2.0.0", Cid: "/ipfs/a-2.0.0", Yanked: true},
+	)
+	tests := []struct {
+		name     string
+		plugin   string
+		spec     string
+		want     string
+		wantCode codes.Code
+	}{
+		{"latest skips yanked", "a", "latest", "1.0.0", codes.OK},
+		{"empty is latest", "a", "", "1.0.0", codes.OK},
+		{"caret skips yanked", "a", "^1", "1.0.0", codes.OK},
+		{"tilde", "a", "~1.0", "1.0.0", codes.OK},
+		{"and range", "a", ">=1, <2", "1.0.0", codes.OK},
+		{"range of only yanked", "a", ">=2, <3", "", codes.NotFound},
+		{"exact yanked", "a", "1.1.0", "1.1.0", codes.OK},
+		{"exact pre-release", "a", "1.2.0-beta.1", "1.2.0-beta.1", codes.OK},
+		{"pre-release range", "a", "^1.2.0-beta.0", "1.2.0-beta.1", codes.OK},
+		{"legacy by canonical form", "a", "0.9.0", "0.9", codes.OK},
+		{"legacy by stored form", "a", "0.9", "0.9", codes.OK},
+		{"no match", "a", "^5", "", codes.NotFound},
+		{"unknown plugin", "b", "", "", codes.NotFound},
+		{"invalid range", "a", "not a range!", "", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.resolvePlugin(context.Background(), tt.plugin, tt.spec)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("error = %v, want code %s", err, tt.wantCode)
+			}
+			if err == nil && got.Version != tt.want {
+				t.Errorf("resolved %s, want %s", got.Version, tt.want)
+			}
+		})
+	}
+}
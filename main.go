@@ -1,33 +1,58 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"os"
+	"os/signal"
 	"spacecore_registry/internal"
-	// "github.com/ipfs/kubo/client/rpc"
+	"spacecore_registry/internal/config"
+	"spacecore_registry/internal/lifecycle"
+	"syscall"
 )
 
-func main() {
-	// ctx := context.Background()
-	h, dht, err := internal.SetupHost()
+// Exit codes.
+const (
+	exitOK = 0
+	// exitFailure means a component failed while running or did not stop
+	// cleanly.
+	exitFailure = 1
+	// exitConfig means the flags or configuration were invalid.
+	exitConfig = 2
+	// exitStartup means a component failed to start.
+	exitStartup = 3
+)
 
+func main() {
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(exitOK)
+	}
 	if err != nil {
-		log.Fatalf("Failed to set up host: %v", err)
+		log.Printf("Failed to load configuration: %v", err)
+		os.Exit(exitConfig)
 	}
-	// setup routing discovery
-	// rdisc := routing.NewRoutingDiscovery(dht)
-	// rdisc.Advertise(ctx, "spacecore-registry")
-
-	// rt, err := kbucket.NewRoutingTable(
-	// 	20, kbucket.ConvertPeerID(h.ID()), 10*time.Second, h.Peerstore(), time.Second, nil,
-	// )
-
-	// Start the gRPC server
-	internal.Start(h, dht)
-
-	// Log the peer ID
-	log.Printf("Peer ID: %v\n", h.ID())
-
-	// Keep the process alive
-	select {}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		// Restore the default handlers so a second signal exits immediately.
+		stop()
+	}()
+
+	err = internal.Start(ctx, cfg)
+	var startErr *lifecycle.StartError
+	switch {
+	case err == nil:
+		log.Println("Spacecore Registry stopped")
+		os.Exit(exitOK)
+	case errors.As(err, &startErr):
+		log.Printf("Spacecore Registry failed to start: %v", err)
+		os.Exit(exitStartup)
+	default:
+		log.Printf("Spacecore Registry stopped with errors: %v", err)
+		os.Exit(exitFailure)
+	}
 }
@@ -19,8 +19,34 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PluginRegistryClient interface {
 	RegisterPlugin(ctx context.Context, in *RegisterPluginRequest, opts ...grpc.CallOption) (*RegisterPluginResponse, error)
+	// UploadPlugin streams a header followed by the plugin binary in chunks.
+	UploadPlugin(ctx context.Context, opts ...grpc.CallOption) (PluginRegistry_UploadPluginClient, error)
 	DiscoverPlugins(ctx context.Context, in *DiscoverPluginsRequest, opts ...grpc.CallOption) (*DiscoverPluginsResponse, error)
 	GetPlugin(ctx context.Context, in *GetPluginRequest, opts ...grpc.CallOption) (*GetPluginResponse, error)
+	// DownloadPlugin streams the plugin binary in chunks.
+	DownloadPlugin(ctx context.Context, in *DownloadPluginRequest, opts ...grpc.CallOption) (PluginRegistry_DownloadPluginClient, error)
+	// Namespace administration. GetNamespace is public; every other call must
+	// be signed by the current namespace owner.
+	GetNamespace(ctx context.Context, in *GetNamespaceRequest, opts ...grpc.CallOption) (*NamespaceResponse, error)
+	TransferNamespace(ctx context.Context, in *TransferNamespaceRequest, opts ...grpc.CallOption) (*NamespaceResponse, error)
+	AddMaintainer(ctx context.Context, in *MaintainerRequest, opts ...grpc.CallOption) (*NamespaceResponse, error)
+	RemoveMaintainer(ctx context.Context, in *MaintainerRequest, opts ...grpc.CallOption) (*NamespaceResponse, error)
+	SetReplicationPolicy(ctx context.Context, in *SetReplicationPolicyRequest, opts ...grpc.CallOption) (*NamespaceResponse, error)
+	// YankPlugin marks a version as yanked. May be called by the namespace
+	// owner or a maintainer.
+	YankPlugin(ctx context.Context, in *YankPluginRequest, opts ...grpc.CallOption) (*GetPluginResponse, error)
+	// GetPinStatus reports remote pinning progress for a plugin version.
+	GetPinStatus(ctx context.Context, in *GetPinStatusRequest, opts ...grpc.CallOption) (*GetPinStatusResponse, error)
+	// FindProviders lists peers on the DHT that can currently serve a plugin
+	// version.
+	FindProviders(ctx context.Context, in *FindProvidersRequest, opts ...grpc.CallOption) (*FindProvidersResponse, error)
+	// SearchPlugins ranks registered plugins by how well they match a free
+	// text query and how often they are downloaded.
+	SearchPlugins(ctx context.Context, in *SearchPluginsRequest, opts ...grpc.CallOption) (*SearchPluginsResponse, error)
+	// ResolveDependencies picks one version of every plugin needed by the
+	// requirements such that all dependency ranges are satisfied. If no such
+	// set exists it fails with FailedPrecondition explaining the conflict.
+	ResolveDependencies(ctx context.Context, in *ResolveDependenciesRequest, opts ...grpc.CallOption) (*ResolveDependenciesResponse, error)
 }
 
 type pluginRegistryClient struct {
@@ -40,6 +66,40 @@ func (c *pluginRegistryClient) RegisterPlugin(ctx context.Context, in *RegisterP
 	return out, nil
 }
 
+func (c *pluginRegistryClient) UploadPlugin(ctx context.Context, opts ...grpc.CallOption) (PluginRegistry_UploadPluginClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PluginRegistry_ServiceDesc.Streams[0], "/pb.PluginRegistry/UploadPlugin", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pluginRegistryUploadPluginClient{stream}
+	return x, nil
+}
+
+type PluginRegistry_UploadPluginClient interface {
+	Send(*UploadPluginRequest) error
+	CloseAndRecv() (*RegisterPluginResponse, error)
+	grpc.ClientStream
+}
+
+type pluginRegistryUploadPluginClient struct {
+	grpc.ClientStream
+}
+
+func (x *pluginRegistryUploadPluginClient) Send(m *UploadPluginRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *pluginRegistryUploadPluginClient) CloseAndRecv() (*RegisterPluginResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(RegisterPluginResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *pluginRegistryClient) DiscoverPlugins(ctx context.Context, in *DiscoverPluginsRequest, opts ...grpc.CallOption) (*DiscoverPluginsResponse, error) {
 	out := new(DiscoverPluginsResponse)
 	err := c.cc.Invoke(ctx, "/pb.PluginRegistry/DiscoverPlugins", in, out, opts...)
@@ -58,13 +118,161 @@ func (c *pluginRegistryClient) GetPlugin(ctx context.Context, in *GetPluginReque
 	return out, nil
 }
 
+func (c *pluginRegistryClient) DownloadPlugin(ctx context.Context, in *DownloadPluginRequest, opts ...grpc.CallOption) (PluginRegistry_DownloadPluginClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PluginRegistry_ServiceDesc.Streams[1], "/pb.PluginRegistry/DownloadPlugin", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pluginRegistryDownloadPluginClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PluginRegistry_DownloadPluginClient interface {
+	Recv() (*DownloadPluginResponse, error)
+	grpc.ClientStream
+}
+
+type pluginRegistryDownloadPluginClient struct {
+	grpc.ClientStream
+}
+
+func (x *pluginRegistryDownloadPluginClient) Recv() (*DownloadPluginResponse, error) {
+	m := new(DownloadPluginResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *pluginRegistryClient) GetNamespace(ctx context.Context, in *GetNamespaceRequest, opts ...grpc.CallOption) (*NamespaceResponse, error) {
+	out := new(NamespaceResponse)
+	err := c.cc.Invoke(ctx, "/pb.PluginRegistry/GetNamespace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginRegistryClient) TransferNamespace(ctx context.Context, in *TransferNamespaceRequest, opts ...grpc.CallOption) (*NamespaceResponse, error) {
+	out := new(NamespaceResponse)
+	err := c.cc.Invoke(ctx, "/pb.PluginRegistry/TransferNamespace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginRegistryClient) AddMaintainer(ctx context.Context, in *MaintainerRequest, opts ...grpc.CallOption) (*NamespaceResponse, error) {
+	out := new(NamespaceResponse)
+	err := c.cc.Invoke(ctx, "/pb.PluginRegistry/AddMaintainer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginRegistryClient) RemoveMaintainer(ctx context.Context, in *MaintainerRequest, opts ...grpc.CallOption) (*NamespaceResponse, error) {
+	out := new(NamespaceResponse)
+	err := c.cc.Invoke(ctx, "/pb.PluginRegistry/RemoveMaintainer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginRegistryClient) SetReplicationPolicy(ctx context.Context, in *SetReplicationPolicyRequest, opts ...grpc.CallOption) (*NamespaceResponse, error) {
+	out := new(NamespaceResponse)
+	err := c.cc.Invoke(ctx, "/pb.PluginRegistry/SetReplicationPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginRegistryClient) YankPlugin(ctx context.Context, in *YankPluginRequest, opts ...grpc.CallOption) (*GetPluginResponse, error) {
+	out := new(GetPluginResponse)
+	err := c.cc.Invoke(ctx, "/pb.PluginRegistry/YankPlugin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginRegistryClient) GetPinStatus(ctx context.Context, in *GetPinStatusRequest, opts ...grpc.CallOption) (*GetPinStatusResponse, error) {
+	out := new(GetPinStatusResponse)
+	err := c.cc.Invoke(ctx, "/pb.PluginRegistry/GetPinStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginRegistryClient) FindProviders(ctx context.Context, in *FindProvidersRequest, opts ...grpc.CallOption) (*FindProvidersResponse, error) {
+	out := new(FindProvidersResponse)
+	err := c.cc.Invoke(ctx, "/pb.PluginRegistry/FindProviders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginRegistryClient) SearchPlugins(ctx context.Context, in *SearchPluginsRequest, opts ...grpc.CallOption) (*SearchPluginsResponse, error) {
+	out := new(SearchPluginsResponse)
+	err := c.cc.Invoke(ctx, "/pb.PluginRegistry/SearchPlugins", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginRegistryClient) ResolveDependencies(ctx context.Context, in *ResolveDependenciesRequest, opts ...grpc.CallOption) (*ResolveDependenciesResponse, error) {
+	out := new(ResolveDependenciesResponse)
+	err := c.cc.Invoke(ctx, "/pb.PluginRegistry/ResolveDependencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PluginRegistryServer is the server API for PluginRegistry service.
 // All implementations must embed UnimplementedPluginRegistryServer
 // for forward compatibility
 type PluginRegistryServer interface {
 	RegisterPlugin(context.Context, *RegisterPluginRequest) (*RegisterPluginResponse, error)
+	// UploadPlugin streams a header followed by the plugin binary in chunks.
+	UploadPlugin(PluginRegistry_UploadPluginServer) error
 	DiscoverPlugins(context.Context, *DiscoverPluginsRequest) (*DiscoverPluginsResponse, error)
 	GetPlugin(context.Context, *GetPluginRequest) (*GetPluginResponse, error)
+	// DownloadPlugin streams the plugin binary in chunks.
+	DownloadPlugin(*DownloadPluginRequest, PluginRegistry_DownloadPluginServer) error
+	// Namespace administration. GetNamespace is public; every other call must
+	// be signed by the current namespace owner.
+	GetNamespace(context.Context, *GetNamespaceRequest) (*NamespaceResponse, error)
+	TransferNamespace(context.Context, *TransferNamespaceRequest) (*NamespaceResponse, error)
+	AddMaintainer(context.Context, *MaintainerRequest) (*NamespaceResponse, error)
+	RemoveMaintainer(context.Context, *MaintainerRequest) (*NamespaceResponse, error)
+	SetReplicationPolicy(context.Context, *SetReplicationPolicyRequest) (*NamespaceResponse, error)
+	// YankPlugin marks a version as yanked. May be called by the namespace
+	// owner or a maintainer.
+	YankPlugin(context.Context, *YankPluginRequest) (*GetPluginResponse, error)
+	// GetPinStatus reports remote pinning progress for a plugin version.
+	GetPinStatus(context.Context, *GetPinStatusRequest) (*GetPinStatusResponse, error)
+	// FindProviders lists peers on the DHT that can currently serve a plugin
+	// version.
+	FindProviders(context.Context, *FindProvidersRequest) (*FindProvidersResponse, error)
+	// SearchPlugins ranks registered plugins by how well they match a free
+	// text query and how often they are downloaded.
+	SearchPlugins(context.Context, *SearchPluginsRequest) (*SearchPluginsResponse, error)
+	// ResolveDependencies picks one version of every plugin needed by the
+	// requirements such that all dependency ranges are satisfied. If no such
+	// set exists it fails with FailedPrecondition explaining the conflict.
+	ResolveDependencies(context.Context, *ResolveDependenciesRequest) (*ResolveDependenciesResponse, error)
 	mustEmbedUnimplementedPluginRegistryServer()
 }
 
@@ -75,12 +283,48 @@ type UnimplementedPluginRegistryServer struct {
 func (UnimplementedPluginRegistryServer) RegisterPlugin(context.Context, *RegisterPluginRequest) (*RegisterPluginResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterPlugin not implemented")
 }
+func (UnimplementedPluginRegistryServer) UploadPlugin(PluginRegistry_UploadPluginServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadPlugin not implemented")
+}
 func (UnimplementedPluginRegistryServer) DiscoverPlugins(context.Context, *DiscoverPluginsRequest) (*DiscoverPluginsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DiscoverPlugins not implemented")
 }
 func (UnimplementedPluginRegistryServer) GetPlugin(context.Context, *GetPluginRequest) (*GetPluginResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPlugin not implemented")
 }
+func (UnimplementedPluginRegistryServer) DownloadPlugin(*DownloadPluginRequest, PluginRegistry_DownloadPluginServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadPlugin not implemented")
+}
+func (UnimplementedPluginRegistryServer) GetNamespace(context.Context, *GetNamespaceRequest) (*NamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNamespace not implemented")
+}
+func (UnimplementedPluginRegistryServer) TransferNamespace(context.Context, *TransferNamespaceRequest) (*NamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferNamespace not implemented")
+}
+func (UnimplementedPluginRegistryServer) AddMaintainer(context.Context, *MaintainerRequest) (*NamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMaintainer not implemented")
+}
+func (UnimplementedPluginRegistryServer) RemoveMaintainer(context.Context, *MaintainerRequest) (*NamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMaintainer not implemented")
+}
+func (UnimplementedPluginRegistryServer) SetReplicationPolicy(context.Context, *SetReplicationPolicyRequest) (*NamespaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReplicationPolicy not implemented")
+}
+func (UnimplementedPluginRegistryServer) YankPlugin(context.Context, *YankPluginRequest) (*GetPluginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method YankPlugin not implemented")
+}
+func (UnimplementedPluginRegistryServer) GetPinStatus(context.Context, *GetPinStatusRequest) (*GetPinStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPinStatus not implemented")
+}
+func (UnimplementedPluginRegistryServer) FindProviders(context.Context, *FindProvidersRequest) (*FindProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindProviders not implemented")
+}
+func (UnimplementedPluginRegistryServer) SearchPlugins(context.Context, *SearchPluginsRequest) (*SearchPluginsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchPlugins not implemented")
+}
+func (UnimplementedPluginRegistryServer) ResolveDependencies(context.Context, *ResolveDependenciesRequest) (*ResolveDependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveDependencies not implemented")
+}
 func (UnimplementedPluginRegistryServer) mustEmbedUnimplementedPluginRegistryServer() {}
 
 // UnsafePluginRegistryServer may be embedded to opt out of forward compatibility for this service.
@@ -112,6 +356,32 @@ func _PluginRegistry_RegisterPlugin_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PluginRegistry_UploadPlugin_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PluginRegistryServer).UploadPlugin(&pluginRegistryUploadPluginServer{stream})
+}
+
+type PluginRegistry_UploadPluginServer interface {
+	SendAndClose(*RegisterPluginResponse) error
+	Recv() (*UploadPluginRequest, error)
+	grpc.ServerStream
+}
+
+type pluginRegistryUploadPluginServer struct {
+	grpc.ServerStream
+}
+
+func (x *pluginRegistryUploadPluginServer) SendAndClose(m *RegisterPluginResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *pluginRegistryUploadPluginServer) Recv() (*UploadPluginRequest, error) {
+	m := new(UploadPluginRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _PluginRegistry_DiscoverPlugins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DiscoverPluginsRequest)
 	if err := dec(in); err != nil {
@@ -148,6 +418,207 @@ func _PluginRegistry_GetPlugin_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PluginRegistry_DownloadPlugin_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadPluginRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PluginRegistryServer).DownloadPlugin(m, &pluginRegistryDownloadPluginServer{stream})
+}
+
+type PluginRegistry_DownloadPluginServer interface {
+	Send(*DownloadPluginResponse) error
+	grpc.ServerStream
+}
+
+type pluginRegistryDownloadPluginServer struct {
+	grpc.ServerStream
+}
+
+func (x *pluginRegistryDownloadPluginServer) Send(m *DownloadPluginResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PluginRegistry_GetNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginRegistryServer).GetNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.PluginRegistry/GetNamespace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginRegistryServer).GetNamespace(ctx, req.(*GetNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginRegistry_TransferNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginRegistryServer).TransferNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.PluginRegistry/TransferNamespace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginRegistryServer).TransferNamespace(ctx, req.(*TransferNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginRegistry_AddMaintainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaintainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginRegistryServer).AddMaintainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.PluginRegistry/AddMaintainer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginRegistryServer).AddMaintainer(ctx, req.(*MaintainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginRegistry_RemoveMaintainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaintainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginRegistryServer).RemoveMaintainer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.PluginRegistry/RemoveMaintainer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginRegistryServer).RemoveMaintainer(ctx, req.(*MaintainerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginRegistry_SetReplicationPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReplicationPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginRegistryServer).SetReplicationPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.PluginRegistry/SetReplicationPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginRegistryServer).SetReplicationPolicy(ctx, req.(*SetReplicationPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginRegistry_YankPlugin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(YankPluginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginRegistryServer).YankPlugin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.PluginRegistry/YankPlugin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginRegistryServer).YankPlugin(ctx, req.(*YankPluginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginRegistry_GetPinStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPinStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginRegistryServer).GetPinStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.PluginRegistry/GetPinStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginRegistryServer).GetPinStatus(ctx, req.(*GetPinStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginRegistry_FindProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginRegistryServer).FindProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.PluginRegistry/FindProviders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginRegistryServer).FindProviders(ctx, req.(*FindProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginRegistry_SearchPlugins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchPluginsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginRegistryServer).SearchPlugins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.PluginRegistry/SearchPlugins",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginRegistryServer).SearchPlugins(ctx, req.(*SearchPluginsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginRegistry_ResolveDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginRegistryServer).ResolveDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.PluginRegistry/ResolveDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginRegistryServer).ResolveDependencies(ctx, req.(*ResolveDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PluginRegistry_ServiceDesc is the grpc.ServiceDesc for PluginRegistry service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -167,7 +638,58 @@ var PluginRegistry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPlugin",
 			Handler:    _PluginRegistry_GetPlugin_Handler,
 		},
+		{
+			MethodName: "GetNamespace",
+			Handler:    _PluginRegistry_GetNamespace_Handler,
+		},
+		{
+			MethodName: "TransferNamespace",
+			Handler:    _PluginRegistry_TransferNamespace_Handler,
+		},
+		{
+			MethodName: "AddMaintainer",
+			Handler:    _PluginRegistry_AddMaintainer_Handler,
+		},
+		{
+			MethodName: "RemoveMaintainer",
+			Handler:    _PluginRegistry_RemoveMaintainer_Handler,
+		},
+		{
+			MethodName: "SetReplicationPolicy",
+			Handler:    _PluginRegistry_SetReplicationPolicy_Handler,
+		},
+		{
+			MethodName: "YankPlugin",
+			Handler:    _PluginRegistry_YankPlugin_Handler,
+		},
+		{
+			MethodName: "GetPinStatus",
+			Handler:    _PluginRegistry_GetPinStatus_Handler,
+		},
+		{
+			MethodName: "FindProviders",
+			Handler:    _PluginRegistry_FindProviders_Handler,
+		},
+		{
+			MethodName: "SearchPlugins",
+			Handler:    _PluginRegistry_SearchPlugins_Handler,
+		},
+		{
+			MethodName: "ResolveDependencies",
+			Handler:    _PluginRegistry_ResolveDependencies_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadPlugin",
+			Handler:       _PluginRegistry_UploadPlugin_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadPlugin",
+			Handler:       _PluginRegistry_DownloadPlugin_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pb/spacecore.proto",
 }